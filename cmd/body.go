@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readBody returns the text supplied through a --body flag or its --body-file companion,
+// where a body file of "-" reads from stdin. The flags are mutually exclusive, and text
+// read from a file must not be blank. An empty string is returned when neither is set.
+// With normalizeNewlines, CRLF line endings are converted to LF.
+func readBody(stdin io.Reader, bodyFlag, bodyFileFlag string, normalizeNewlines bool) (string, error) {
+	if bodyFileFlag == "" {
+		return normalizeBody(bodyFlag, normalizeNewlines), nil
+	}
+	if bodyFlag != "" {
+		return "", errors.New("specify only one of --body or --body-file")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if bodyFileFlag == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(bodyFileFlag)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read --body-file: %w", err)
+	}
+
+	body := string(data)
+	if strings.TrimSpace(body) == "" {
+		return "", errors.New("--body-file is empty")
+	}
+	return normalizeBody(body, normalizeNewlines), nil
+}
+
+// normalizeBody converts CRLF line endings to LF when enabled; bodies are sent
+// byte-for-byte otherwise.
+func normalizeBody(body string, normalizeNewlines bool) string {
+	if !normalizeNewlines {
+		return body
+	}
+	return strings.ReplaceAll(body, "\r\n", "\n")
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	body, err := readBody(strings.NewReader("ignored"), "inline", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "inline", body)
+
+	body, err = readBody(strings.NewReader("from stdin\n"), "", "-", false)
+	require.NoError(t, err)
+	assert.Equal(t, "from stdin\n", body)
+
+	path := filepath.Join(t.TempDir(), "body.md")
+	require.NoError(t, os.WriteFile(path, []byte("from file"), 0o600))
+	body, err = readBody(nil, "", path, false)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", body)
+
+	body, err = readBody(nil, "", "", false)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	_, err = readBody(nil, "inline", "-", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of --body or --body-file")
+
+	_, err = readBody(strings.NewReader(" \n"), "", "-", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--body-file is empty")
+
+	_, err = readBody(nil, "", filepath.Join(t.TempDir(), "missing.md"), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read --body-file")
+}
+
+func TestReadBodyNormalizeNewlines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.md")
+	require.NoError(t, os.WriteFile(path, []byte("first\r\nsecond\r\n"), 0o600))
+
+	body, err := readBody(nil, "", path, false)
+	require.NoError(t, err)
+	assert.Equal(t, "first\r\nsecond\r\n", body, "CRLF is preserved by default")
+
+	body, err = readBody(nil, "", path, true)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", body)
+
+	body, err = readBody(nil, "inline\r\ntext", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, "inline\ntext", body)
+}
+
+func TestCommentsReplyNormalizeNewlines(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{args: nil, want: "line one\r\nline two"},
+		{args: []string{"--normalize-newlines"}, want: "line one\nline two"},
+	} {
+		var posted string
+		apiClientFactory = func(host string) ghcli.API { return replyFlowFake(t, &posted) }
+
+		root := newRootCommand()
+		root.SetIn(strings.NewReader("line one\r\nline two"))
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body-file", "-", "--repo", "octo/demo", "7"}, tc.args...))
+
+		require.NoError(t, root.Execute())
+		assert.Equal(t, tc.want, posted)
+	}
+}
+
+func TestReviewAddCommentBodyFromStdin(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted interface{}
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		posted = variables["input"].(map[string]interface{})["body"]
+		return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{
+			"id": "THREAD1", "path": "scenario.md", "isOutdated": false, "line": 12,
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetIn(strings.NewReader("multi\n\nline note"))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "--add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--line", "12", "--body-file", "-", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "multi\n\nline note", posted)
+}
+
+func TestReviewSubmitBodyFromStdin(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted interface{}
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		posted = variables["input"].(map[string]interface{})["body"]
+		return assignJSON(result, obj{"submitPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_kwM123"}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetIn(strings.NewReader("Please cover edge cases\n"))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "REQUEST_CHANGES", "--body-file", "-", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "Please cover edge cases", posted)
+}
+
+func TestReviewBodyFlagsMutuallyExclusive(t *testing.T) {
+	root := newRootCommand()
+	root.SetIn(strings.NewReader("stdin"))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--body", "inline", "--body-file", "-", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of --body or --body-file")
+}
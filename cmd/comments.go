@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
 	"github.com/agynio/gh-pr-review/internal/comments"
 	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/threads"
 )
 
 type commentsOptions struct {
@@ -20,13 +26,13 @@ func newCommentsCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "comments",
-		Short: "Reply to pull request review threads",
+		Short: "Read and reply to pull request review threads",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := cmd.Help(); err != nil {
 				return err
 			}
-			return errors.New("use 'gh pr-review comments reply' to respond to a review thread; run 'gh pr-review review view' to locate thread IDs")
+			return errors.New("use 'gh pr-review comments reply' to respond to a review thread or 'gh pr-review comments list' to read one; run 'gh pr-review review view' to locate thread IDs")
 		},
 	}
 
@@ -34,6 +40,8 @@ func newCommentsCommand() *cobra.Command {
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
 	cmd.AddCommand(newCommentsReplyCommand(opts))
+	cmd.AddCommand(newCommentsListCommand(opts))
+	cmd.AddCommand(newCommentsApplySuggestionCommand(opts))
 
 	return cmd
 }
@@ -62,41 +70,230 @@ func newCommentsReplyCommand(parent *commentsOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "Review thread identifier to reply to")
+	cmd.Flags().Int64Var(&opts.CommentID, "comment-id", 0, "REST (database) ID of a review comment; replies to its thread instead of --thread-id")
+	cmd.Flags().StringVar(&opts.ThreadURL, "thread-url", "", "Comment permalink (…/pull/N#discussion_r<id>); replies to that comment's thread")
 	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "GraphQL review identifier when replying inside a pending review")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply text")
-	_ = cmd.MarkFlagRequired("thread-id")
-	_ = cmd.MarkFlagRequired("body")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read reply text from a file (use \"-\" for stdin)")
+	cmd.Flags().StringVar(&opts.BodyTemplate, "body-template", "", "Reply text as a Go text/template (built-ins: thread_id, author, path, line)")
+	cmd.Flags().StringArrayVar(&opts.TemplateVars, "template-var", nil, "Template variable as key=value for --body-template (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-template")
+	cmd.MarkFlagsMutuallyExclusive("body-file", "body-template")
+	cmd.Flags().BoolVar(&opts.NormalizeNewlines, "normalize-newlines", false, "Convert CRLF line endings in the body to LF before sending")
+	cmd.Flags().BoolVar(&opts.Resolve, "resolve", false, "Resolve the thread after replying")
+	cmd.Flags().BoolVar(&opts.Quote, "quote", false, "Prepend the thread's most recent comment to the reply as a blockquote")
+	cmd.Flags().BoolVar(&opts.QuoteAuthor, "quote-author", false, "Start the quote with \"@author wrote:\" (requires --quote)")
+	cmd.Flags().BoolVar(&opts.IncludeAuthorID, "include-author-id", false, "Include the reply author's numeric GitHub user ID (author_id)")
+	cmd.Flags().StringVar(&opts.BatchFile, "batch-file", "", "Post replies from a JSON array of {thread_id, review_id?, body} (use \"-\" for stdin)")
+	cmd.MarkFlagsMutuallyExclusive("thread-id", "comment-id", "thread-url", "batch-file")
+	cmd.MarkFlagsOneRequired("thread-id", "comment-id", "thread-url", "batch-file")
+	for _, flag := range []string{"body", "body-file", "body-template", "template-var", "review-id", "resolve", "quote", "quote-author"} {
+		cmd.MarkFlagsMutuallyExclusive("batch-file", flag)
+	}
 
 	return cmd
 }
 
-type commentsReplyOptions struct {
-	Repo     string
-	Pull     int
+func newCommentsListCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsListOptions{commentsOptions: parent}
+
+	cmd := &cobra.Command{
+		Use:   "list [<number> | <url>]",
+		Short: "List the comments of one review thread, oldest first",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if strings.TrimSpace(opts.ThreadID) == "" {
+				return errors.New("--thread-id is required")
+			}
+			return runCommentsList(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+
+	return cmd
+}
+
+type commentsListOptions struct {
+	*commentsOptions
+
 	Selector string
 	ThreadID string
-	ReviewID string
-	Body     string
 }
 
-func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
-	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+func runCommentsList(cmd *cobra.Command, opts *commentsListOptions) error {
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
 	if err != nil {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	service := comments.NewService(newAPIClient(cmd, identity.Host))
+	list, err := service.List(identity, opts.ThreadID)
 	if err != nil {
 		return err
 	}
+	return encodeJSON(cmd, list)
+}
 
-	service := comments.NewService(apiClientFactory(identity.Host))
+func newCommentsApplySuggestionCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsApplySuggestionOptions{commentsOptions: parent}
+
+	cmd := &cobra.Command{
+		Use:   "apply-suggestion [<number> | <url>]",
+		Short: "Commit a review comment's suggested change to the pull request branch",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runCommentsApplySuggestion(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "GraphQL node ID of the review comment holding the suggestion (PRRC_...)")
+	cmd.Flags().StringVar(&opts.Message, "message", comments.DefaultSuggestionMessage, "Commit message")
+
+	return cmd
+}
+
+type commentsApplySuggestionOptions struct {
+	*commentsOptions
+
+	Selector  string
+	CommentID string
+	Message   string
+}
+
+func runCommentsApplySuggestion(cmd *cobra.Command, opts *commentsApplySuggestionOptions) error {
+	commentID := strings.TrimSpace(opts.CommentID)
+	if commentID == "" {
+		return errors.New("--comment-id is required")
+	}
+	if !strings.HasPrefix(commentID, "PRRC_") {
+		return fmt.Errorf("invalid --comment-id %q: must be a GraphQL node id (PRRC_...)", opts.CommentID)
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(newAPIClient(cmd, identity.Host))
+	applied, err := service.ApplySuggestion(identity, commentID, opts.Message)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, applied)
+}
+
+type commentsReplyOptions struct {
+	Repo              string
+	Pull              int
+	Selector          string
+	ThreadID          string
+	CommentID         int64
+	ThreadURL         string
+	ReviewID          string
+	Body              string
+	BodyFile          string
+	BodyTemplate      string
+	TemplateVars      []string
+	NormalizeNewlines bool
+	Resolve           bool
+	Quote             bool
+	QuoteAuthor       bool
+
+	IncludeAuthorID bool
+	BatchFile       string
+}
+
+// replyResult is the reply command output; resolution fields appear only with
+// --resolve and author_id only with --include-author-id.
+type replyResult struct {
+	CommentNodeID string                `json:"comment_node_id"`
+	AuthorID      *int64                `json:"author_id,omitempty"`
+	Resolution    *threads.ActionResult `json:"resolution,omitempty"`
+	ResolveError  string                `json:"resolve_error,omitempty"`
+}
+
+func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
+	if opts.BatchFile != "" {
+		return runCommentsReplyBatch(cmd, opts)
+	}
+	if opts.QuoteAuthor && !opts.Quote {
+		return errors.New("--quote-author can only be used with --quote")
+	}
+
+	if len(opts.TemplateVars) > 0 && opts.BodyTemplate == "" {
+		return errors.New("--template-var can only be used with --body-template")
+	}
+
+	var (
+		body         string
+		bodyTemplate *template.Template
+		templateVars map[string]string
+		err          error
+	)
+	if opts.BodyTemplate != "" {
+		bodyTemplate, err = comments.ParseBodyTemplate(opts.BodyTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid --body-template: %w", err)
+		}
+		templateVars, err = parseTemplateVars(opts.TemplateVars)
+		if err != nil {
+			return err
+		}
+	} else {
+		body, err = readBody(cmd.InOrStdin(), opts.Body, opts.BodyFile, opts.NormalizeNewlines)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(body) == "" {
+			return errors.New("--body, --body-file, or --body-template is required")
+		}
+	}
+
+	commentID := opts.CommentID
+	var identity resolver.Identity
+	if strings.TrimSpace(opts.ThreadURL) != "" {
+		identity, commentID, err = resolveThreadURL(cmd, opts.ThreadURL, opts.Selector, opts.Pull, opts.Repo)
+	} else {
+		identity, err = resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	}
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(newAPIClient(cmd, identity.Host))
+
+	threadID := opts.ThreadID
+	if commentID != 0 {
+		threadID, err = service.ThreadForComment(identity, commentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if bodyTemplate != nil {
+		body, err = service.RenderBodyTemplate(threadID, bodyTemplate, templateVars)
+		if err != nil {
+			return fmt.Errorf("render --body-template: %w", err)
+		}
+		body = normalizeBody(body, opts.NormalizeNewlines)
+		if strings.TrimSpace(body) == "" {
+			return errors.New("--body-template rendered an empty reply")
+		}
+	}
 
 	reply, err := service.Reply(identity, comments.ReplyOptions{
-		ThreadID: opts.ThreadID,
-		ReviewID: opts.ReviewID,
-		Body:     opts.Body,
+		ThreadID:    threadID,
+		ReviewID:    opts.ReviewID,
+		Body:        body,
+		Quote:       opts.Quote,
+		QuoteAuthor: opts.QuoteAuthor,
 	})
 	if err != nil {
 		return err
@@ -104,5 +301,139 @@ func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
 	if reply.CommentNodeID == "" {
 		return errors.New("reply response missing comment node id")
 	}
-	return encodeJSON(cmd, map[string]string{"comment_node_id": reply.CommentNodeID})
+
+	result := replyResult{CommentNodeID: reply.CommentNodeID}
+	if opts.IncludeAuthorID {
+		result.AuthorID = reply.AuthorID
+	}
+	if opts.Resolve {
+		// The reply is already posted; a failed resolve is reported, not fatal.
+		resolution, err := threads.NewService(newAPIClient(cmd, identity.Host)).Resolve(identity, threads.ActionOptions{ThreadID: threadID})
+		if err != nil {
+			result.ResolveError = err.Error()
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: reply posted but thread was not resolved: %v\n", err)
+		} else {
+			result.Resolution = &resolution
+		}
+	}
+	return encodeJSON(cmd, result)
+}
+
+// parseTemplateVars turns repeated --template-var key=value flags into a map;
+// later flags win for the same key.
+func parseTemplateVars(values []string) (map[string]string, error) {
+	vars := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --template-var %q: must be key=value", value)
+		}
+		vars[key] = val
+	}
+	return vars, nil
+}
+
+// replyBatchEntry is one element of a --batch-file array.
+type replyBatchEntry struct {
+	ThreadID string `json:"thread_id"`
+	ReviewID string `json:"review_id,omitempty"`
+	Body     string `json:"body"`
+}
+
+// replyBatchResult reports the outcome of one batch entry, in input order.
+type replyBatchResult struct {
+	Index         int    `json:"index"`
+	ThreadID      string `json:"thread_id"`
+	CommentNodeID string `json:"comment_node_id,omitempty"`
+	AuthorID      *int64 `json:"author_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// runCommentsReplyBatch posts every entry of --batch-file, recording per-entry
+// failures instead of stopping. The results are always printed; the command
+// fails afterwards when any entry failed.
+func runCommentsReplyBatch(cmd *cobra.Command, opts *commentsReplyOptions) error {
+	var (
+		data []byte
+		err  error
+	)
+	if opts.BatchFile == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(opts.BatchFile)
+	}
+	if err != nil {
+		return fmt.Errorf("read --batch-file: %w", err)
+	}
+	var entries []replyBatchEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parse --batch-file: expected a JSON array of {thread_id, review_id?, body}: %w", err)
+	}
+	if len(entries) == 0 {
+		return errors.New("--batch-file contains no replies")
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+	service := comments.NewService(newAPIClient(cmd, identity.Host))
+
+	results := make([]replyBatchResult, len(entries))
+	failed := 0
+	for i, entry := range entries {
+		results[i] = replyBatchResult{Index: i, ThreadID: entry.ThreadID}
+		reply, err := service.Reply(identity, comments.ReplyOptions{
+			ThreadID: entry.ThreadID,
+			ReviewID: entry.ReviewID,
+			Body:     normalizeBody(entry.Body, opts.NormalizeNewlines),
+		})
+		if err != nil {
+			results[i].Error = err.Error()
+			failed++
+			continue
+		}
+		results[i].CommentNodeID = reply.CommentNodeID
+		if opts.IncludeAuthorID {
+			results[i].AuthorID = reply.AuthorID
+		}
+	}
+
+	if err := encodeJSON(cmd, results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d batch replies failed", failed, len(entries))
+	}
+	return nil
+}
+
+// resolveThreadURL extracts the pull request and comment ID from --thread-url.
+// An explicit selector or --pr must name the same pull request as the link,
+// and --repo alone must name the same repository.
+func resolveThreadURL(cmd *cobra.Command, threadURL, selector string, pull int, repo string) (resolver.Identity, int64, error) {
+	linked, anchor, err := resolver.ParsePermalink(strings.TrimSpace(threadURL))
+	if err != nil {
+		return resolver.Identity{}, 0, err
+	}
+	if anchor.Kind != resolver.AnchorComment {
+		return resolver.Identity{}, 0, fmt.Errorf("--thread-url must link to a review comment (#discussion_r<id>), got %q", threadURL)
+	}
+	if strings.TrimSpace(selector) == "" && pull <= 0 {
+		repo = strings.TrimSpace(repo)
+		if repo != "" && !strings.EqualFold(repo, linked.Owner+"/"+linked.Repo) {
+			return resolver.Identity{}, 0, fmt.Errorf("--thread-url points at %s but --repo is %s", linked.URL(), repo)
+		}
+		return linked, anchor.ID, nil
+	}
+
+	identity, err := resolveIdentity(cmd, selector, pull, repo)
+	if err != nil {
+		return resolver.Identity{}, 0, err
+	}
+	if !strings.EqualFold(identity.Owner, linked.Owner) || !strings.EqualFold(identity.Repo, linked.Repo) || identity.Number != linked.Number {
+		return resolver.Identity{}, 0, fmt.Errorf("--thread-url points at %s but the selected pull request is %s", linked.URL(), identity.URL())
+	}
+	return identity, anchor.ID, nil
 }
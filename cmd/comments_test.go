@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -204,6 +205,213 @@ func TestCommentsReplyCommandWithoutReviewID(t *testing.T) {
 	assert.Equal(t, "PRRC_reply", payload["comment_node_id"])
 }
 
+// replyFlowFake serves the GraphQL calls issued by comments reply and records the posted body.
+func replyFlowFake(t *testing.T, postedBody *string) *commandFakeAPI {
+	t.Helper()
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input, ok := variables["input"].(map[string]interface{})
+			require.True(t, ok)
+			body, _ := input["body"].(string)
+			*postedBody = body
+			return assignJSON(result, obj{"addPullRequestReviewThreadReply": obj{"comment": obj{
+				"id":          "PRRC_reply",
+				"body":        body,
+				"publishedAt": "2025-12-03T10:00:00Z",
+				"author":      obj{"login": "octocat"},
+			}}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, obj{"node": obj{
+				"id":        "PRRC_reply",
+				"body":      *postedBody,
+				"path":      "internal/service.go",
+				"url":       "https://example.com/comment",
+				"createdAt": "2025-12-03T10:00:00Z",
+				"updatedAt": "2025-12-03T10:00:00Z",
+				"author":    obj{"login": "octocat", "databaseId": 583231},
+			}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "isResolved": false, "isOutdated": false}})
+		case strings.Contains(query, "PullRequestReviewThreadContext"):
+			return assignJSON(result, obj{"node": obj{
+				"id":       "PRRT_thread",
+				"path":     "internal/service.go",
+				"line":     27,
+				"comments": obj{"nodes": []obj{{"author": obj{"login": "alice"}}}},
+			}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	return fake
+}
+
+func TestCommentsReplyIncludeAuthorID(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "default", want: `{"comment_node_id":"PRRC_reply"}`},
+		{name: "flag", args: []string{"--include-author-id"}, want: `{"comment_node_id":"PRRC_reply","author_id":583231}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var posted string
+			fake := replyFlowFake(t, &posted)
+			apiClientFactory = func(host string) ghcli.API { return fake }
+
+			root := newRootCommand()
+			stdout := &bytes.Buffer{}
+			root.SetOut(stdout)
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(append([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "ack", "--repo", "octo/demo", "7"}, tc.args...))
+
+			require.NoError(t, root.Execute())
+			assertJSONEqual(t, tc.want, stdout.Bytes())
+		})
+	}
+}
+
+func TestCommentsReplyBatchCollectsPerEntryErrors(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader(`[
+		{"thread_id": "PRRT_thread", "body": "fixed"},
+		{"thread_id": "PRRT_other", "body": "  "}
+	]`))
+	root.SetArgs([]string{"comments", "reply", "--batch-file", "-", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 batch replies failed")
+	assert.Equal(t, "fixed", posted)
+	assertJSONEqual(t, `[
+		{"index":0,"thread_id":"PRRT_thread","comment_node_id":"PRRC_reply"},
+		{"index":1,"thread_id":"PRRT_other","error":"reply body is required"}
+	]`, stdout.Bytes())
+}
+
+func TestCommentsReplyBatchRejectsBody(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--batch-file", "replies.json", "--body", "ack", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "batch-file")
+}
+
+func TestCommentsReplyBodyFromStdin(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	body := "Thanks!\n\n```go\nfmt.Println(\"fixed\")\n```\n"
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetIn(strings.NewReader(body))
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body-file", "-", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, body, posted)
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply"}`, stdout.String())
+}
+
+func TestCommentsReplyBodyFromFile(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	path := filepath.Join(t.TempDir(), "reply.md")
+	require.NoError(t, os.WriteFile(path, []byte("Done in abc123"), 0o600))
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body-file", path, "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "Done in abc123", posted)
+}
+
+func TestCommentsReplyBodyTemplate(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	_, err := runDraftCommand(t, "comments", "reply", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "7",
+		"--body-template", "Thanks @{{.author}}, fixed in {{.commit}}.", "--template-var", "commit=abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "Thanks @alice, fixed in abc123.", posted)
+
+	posted = ""
+	_, err = runDraftCommand(t, "comments", "reply", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "7",
+		"--body-template", "Fixed in {{.commit}}.")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `render --body-template:`)
+	assert.Contains(t, err.Error(), `map has no entry for key "commit"`)
+	assert.Empty(t, posted)
+}
+
+func TestCommentsReplyBodyFlagsValidation(t *testing.T) {
+	cases := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{name: "both", args: []string{"--body", "ack", "--body-file", "-"}, want: "only one of --body or --body-file"},
+		{name: "neither", args: nil, want: "--body, --body-file, or --body-template is required"},
+		{name: "blank stdin", args: []string{"--body-file", "-"}, stdin: "  \n\t", want: "--body-file is empty"},
+		{name: "quote author alone", args: []string{"--body", "ack", "--quote-author"}, want: "--quote-author can only be used with --quote"},
+		{name: "template var alone", args: []string{"--body", "ack", "--template-var", "commit=abc"}, want: "--template-var can only be used with --body-template"},
+		{name: "template and body", args: []string{"--body", "ack", "--body-template", "Thanks"}, want: "[body body-template] were all set"},
+		{name: "unparsable template", args: []string{"--body-template", "Thanks {{.author"}, want: "invalid --body-template: template: body:1: unclosed action"},
+		{name: "malformed template var", args: []string{"--body-template", "Thanks", "--template-var", "commit"}, want: `invalid --template-var "commit": must be key=value`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := newRootCommand()
+			root.SetIn(strings.NewReader(tc.stdin))
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			args := append([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "7"}, tc.args...)
+			root.SetArgs(args)
+
+			err := root.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
 func assignJSON(result interface{}, payload interface{}) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -217,3 +425,272 @@ func TestMain(m *testing.M) {
 	_ = os.Unsetenv("GH_HOST")
 	os.Exit(m.Run())
 }
+
+func TestCommentsReplyResolvesThread(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	replyFlow := fake.graphqlFunc
+	resolved := false
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query ThreadDetails("):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "isResolved": false, "viewerCanResolve": true}})
+		case strings.Contains(query, "mutation ResolveThread("):
+			resolved = true
+			assert.Equal(t, "PRRT_thread", variables["threadId"])
+			return assignJSON(result, obj{"resolveReviewThread": obj{"thread": obj{"id": "PRRT_thread", "isResolved": true}}})
+		default:
+			return replyFlow(query, variables, result)
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "done", "--resolve", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.True(t, resolved)
+	assert.Equal(t, "done", posted)
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply","resolution":{"thread_node_id":"PRRT_thread","is_resolved":true}}`, stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestCommentsReplyResolveDeniedKeepsReply(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	fake := replyFlowFake(t, &posted)
+	replyFlow := fake.graphqlFunc
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query ThreadDetails("):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "isResolved": false, "viewerCanResolve": false}})
+		case strings.Contains(query, "mutation ResolveThread("):
+			t.Fatal("unexpected resolve mutation without permission")
+			return nil
+		default:
+			return replyFlow(query, variables, result)
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "done", "--resolve", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "done", posted)
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply","resolve_error":"viewer cannot resolve this thread"}`, stdout.String())
+	assert.Contains(t, stderr.String(), "warning: reply posted but thread was not resolved")
+}
+
+func TestCommentsReplyByCommentID(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	var repliedThread interface{}
+	fake := replyFlowFake(t, &posted)
+	replyFlow := fake.graphqlFunc
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewThreadCommentIDs"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"reviewThreads": obj{
+				"nodes": []obj{
+					{"id": "PRRT_other", "comments": obj{"nodes": []obj{{"databaseId": 1}}, "pageInfo": obj{"hasNextPage": false}}},
+					{"id": "PRRT_thread", "comments": obj{"nodes": []obj{{"databaseId": 555}}, "pageInfo": obj{"hasNextPage": false}}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}})
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input, _ := variables["input"].(map[string]interface{})
+			repliedThread = input["pullRequestReviewThreadId"]
+		}
+		return replyFlow(query, variables, result)
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--comment-id", "555", "--body", "ack", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "PRRT_thread", repliedThread)
+	assert.Equal(t, "ack", posted)
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply"}`, stdout.String())
+}
+
+func TestCommentsReplyRejectsThreadAndCommentID(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--comment-id", "555", "--body", "ack", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[comment-id thread-id] were all set")
+}
+
+func TestCommentsReplyByThreadURL(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted string
+	var repliedThread interface{}
+	var hosts []string
+	fake := replyFlowFake(t, &posted)
+	replyFlow := fake.graphqlFunc
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewThreadCommentIDs"):
+			assert.Equal(t, "o", variables["owner"])
+			assert.Equal(t, "r", variables["name"])
+			assert.Equal(t, 9, variables["number"])
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"reviewThreads": obj{
+				"nodes": []obj{
+					{"id": "PRRT_other", "comments": obj{"nodes": []obj{{"databaseId": 1}}, "pageInfo": obj{"hasNextPage": false}}},
+					{"id": "PRRT_thread", "comments": obj{"nodes": []obj{{"databaseId": 2}, {"databaseId": 123456}}, "pageInfo": obj{"hasNextPage": false}}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}})
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input, _ := variables["input"].(map[string]interface{})
+			repliedThread = input["pullRequestReviewThreadId"]
+		}
+		return replyFlow(query, variables, result)
+	}
+	apiClientFactory = func(host string) ghcli.API {
+		hosts = append(hosts, host)
+		return fake
+	}
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-url", "https://ghe.example.com/o/r/pull/9#discussion_r123456", "--body", "ack"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "PRRT_thread", repliedThread)
+	assert.Equal(t, "ack", posted)
+	assert.Equal(t, []string{"ghe.example.com"}, hosts)
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply"}`, stdout.String())
+}
+
+func TestCommentsReplyThreadURLValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "review anchor",
+			args: []string{"--thread-url", "https://github.com/o/r/pull/9#pullrequestreview-5"},
+			want: "--thread-url must link to a review comment",
+		},
+		{
+			name: "mismatched pull request",
+			args: []string{"--thread-url", "https://github.com/o/r/pull/9#discussion_r5", "--repo", "o/r", "10"},
+			want: "--thread-url points at https://github.com/o/r/pull/9 but the selected pull request is https://github.com/o/r/pull/10",
+		},
+		{
+			name: "mismatched repo",
+			args: []string{"--thread-url", "https://github.com/o/r/pull/9#discussion_r5", "--repo", "o/other"},
+			want: "--thread-url points at https://github.com/o/r/pull/9 but --repo is o/other",
+		},
+		{
+			name: "combined with thread id",
+			args: []string{"--thread-url", "https://github.com/o/r/pull/9#discussion_r5", "--thread-id", "PRRT_x"},
+			want: "[thread-id thread-url] were all set",
+		},
+		{
+			name: "no thread reference",
+			args: []string{"--repo", "o/r", "9"},
+			want: "at least one of the flags in the group [thread-id comment-id thread-url batch-file] is required",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := newRootCommand()
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(append([]string{"comments", "reply", "--body", "ack"}, tc.args...))
+
+			err := root.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestCommentsListCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "ThreadShow")
+		assert.Equal(t, "PRRT_thread", variables["id"])
+		return assignJSON(result, obj{"node": obj{
+			"id":         "PRRT_thread",
+			"path":       "main.go",
+			"line":       12,
+			"isResolved": false,
+			"isOutdated": false,
+			"comments": obj{
+				"nodes": []obj{
+					{"id": "PRRC_1", "databaseId": 11, "body": "Handle the error", "createdAt": "2025-12-03T10:00:00Z", "diffHunk": "@@ -1 +1 @@", "author": obj{"login": "alice"}},
+					{"id": "PRRC_2", "databaseId": 12, "body": "Done", "createdAt": "2025-12-03T11:00:00Z", "author": obj{"login": "bob"}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			},
+		}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "list", "--repo", "octo/demo", "--thread-id", "PRRT_thread", "7"})
+	require.NoError(t, root.Execute())
+
+	assertJSONEqual(t, `[
+		{"comment_node_id":"PRRC_1","database_id":11,"author_login":"alice","body":"Handle the error","created_at":"2025-12-03T10:00:00Z"},
+		{"comment_node_id":"PRRC_2","database_id":12,"author_login":"bob","body":"Done","created_at":"2025-12-03T11:00:00Z"}
+	]`, stdout.Bytes())
+}
+
+func TestCommentsListRequiresThreadID(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "list", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--thread-id is required")
+}
+
+func TestCommentsApplySuggestionRequiresNodeID(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "apply-suggestion", "--comment-id", "12345", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --comment-id "12345": must be a GraphQL node id (PRRC_...)`)
+}
@@ -1,7 +1,94 @@
 package cmd
 
-import "github.com/agynio/gh-pr-review/internal/ghcli"
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
 
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/autodetect"
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// apiClientFactory returns the gh-backed client unless GH_PR_REVIEW_USE_TOKEN
+// is set or gh is not installed, in which case requests go straight to the
+// GitHub API with a token from the environment. When token mode was asked for
+// and no token is found, every call fails with that error; when gh is merely
+// missing, the gh client is kept so the missing-executable error surfaces.
 var apiClientFactory = func(host string) ghcli.API {
+	forceToken := os.Getenv(ghcli.UseTokenEnv) != ""
+	_, ghErr := ghcli.Executable("")
+	if forceToken || ghErr != nil {
+		client, err := ghcli.NewHTTPClient(host)
+		if err == nil {
+			return client
+		}
+		if forceToken {
+			return unavailableAPI{err: err}
+		}
+	}
 	return &ghcli.Client{Host: host}
 }
+
+// unavailableAPI fails every call with err, for clients that cannot be built.
+type unavailableAPI struct {
+	err error
+}
+
+func (u unavailableAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+	return u.err
+}
+
+func (u unavailableAPI) GraphQL(string, map[string]interface{}, interface{}) error {
+	return u.err
+}
+
+// newAPIClient builds the API client for host bound to the command's context,
+// so the global --timeout applies to every request issued by the command,
+// while --request-timeout bounds each request on its own. With --capture-dir,
+// raw responses are written to that directory, and with --debug, each request
+// is also logged to stderr.
+func newAPIClient(cmd *cobra.Command, host string) ghcli.API {
+	client := apiClientFactory(host)
+	if timeout, _ := cmd.Flags().GetDuration("request-timeout"); timeout > 0 {
+		switch c := client.(type) {
+		case *ghcli.Client:
+			c.RequestTimeout = timeout
+		case *ghcli.HTTPClient:
+			c.RequestTimeout = timeout
+		}
+	}
+	api := ghcli.WithContext(cmd.Context(), client)
+	if dir, _ := cmd.Flags().GetString("capture-dir"); strings.TrimSpace(dir) != "" {
+		api = ghcli.NewCaptureAPI(api, dir)
+	}
+	if persistentBool(cmd, "debug") {
+		api = ghcli.NewDebugAPI(api, cmd.ErrOrStderr())
+	}
+	return api
+}
+
+// detectPullRequest infers the pull request from the checkout; the command
+// context (and so --timeout) bounds the gh calls it makes.
+var detectPullRequest = func(ctx context.Context) (autodetect.Result, error) {
+	return autodetect.DetectContext(ctx, autodetect.Options{})
+}
+
+// openBrowser opens url through gh's browser helper, which honors GH_BROWSER and BROWSER.
+var openBrowser = func(url string) error {
+	executable, err := ghcli.Executable("")
+	if err != nil {
+		return err
+	}
+	output, err := exec.Command(executable, "pr", "view", url, "--web").CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("open %s in browser: %w: %s", url, err, msg)
+		}
+		return fmt.Errorf("open %s in browser: %w", url, err)
+	}
+	return nil
+}
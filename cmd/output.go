@@ -1,17 +1,152 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/report"
 )
 
+// encodeJSON writes payload to stdout, or to --output-file when set. The
+// payload is encoded in full before anything is written, so an encoding
+// failure never leaves partial output behind. With a command's --jsonl flag,
+// array payloads are written one compact element per line.
 func encodeJSON(cmd *cobra.Command, payload interface{}) error {
-	enc := json.NewEncoder(cmd.OutOrStdout())
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
 	enc.SetEscapeHTML(false)
-	if err := enc.Encode(payload); err != nil {
-		return fmt.Errorf("encode json: %w", err)
+	if items, ok := jsonlItems(cmd, payload); ok {
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return fmt.Errorf("encode json: %w", err)
+			}
+		}
+	} else {
+		if persistentBool(cmd, "pretty") {
+			enc.SetIndent("", "  ")
+		}
+		if err := enc.Encode(payload); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+	}
+	if path := outputFile(cmd); path != "" {
+		return writeFileAtomic(path, buf.Bytes())
+	}
+	if _, err := cmd.OutOrStdout().Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write output: %w", err)
 	}
 	return nil
 }
+
+// jsonlItems returns the elements to print one per line when --jsonl is set:
+// the payload itself when it is a slice or array, or its "reviews" field for
+// report objects. Other payloads report ok false and are printed whole.
+func jsonlItems(cmd *cobra.Command, payload interface{}) (items []interface{}, ok bool) {
+	if !persistentBool(cmd, "jsonl") {
+		return nil, false
+	}
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		value = jsonField(value, "reviews")
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+	items = make([]interface{}, value.Len())
+	for i := range items {
+		items[i] = value.Index(i).Interface()
+	}
+	return items, true
+}
+
+// jsonField returns the exported field of a struct value serialized under
+// name, or the zero Value when there is none.
+func jsonField(value reflect.Value, name string) reflect.Value {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.IsExported() && tag == name {
+			return value.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// outputFile returns the --output-file path, or "" when output goes to stdout.
+func outputFile(cmd *cobra.Command) string {
+	path, err := cmd.Flags().GetString("output-file")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(path)
+}
+
+// writeFileAtomic writes data to a temporary file beside path and renames it
+// into place, so readers see either the previous file or the complete new one.
+func writeFileAtomic(path string, data []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write --output-file %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("write --output-file %s: %w", path, err)
+	}
+	if err = tmp.Chmod(0o644); err != nil {
+		return fmt.Errorf("write --output-file %s: %w", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write --output-file %s: %w", path, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write --output-file %s: %w", path, err)
+	}
+	return nil
+}
+
+// isTerminal reports whether w is an interactive terminal.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// textPalette picks the palette for text rendering from the --color mode.
+func textPalette(cmd *cobra.Command, mode string) (report.Palette, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "always":
+		return report.ANSIPalette{}, nil
+	case "never":
+		return report.PlainPalette{}, nil
+	case "", "auto":
+		if os.Getenv("NO_COLOR") != "" || !isTerminal(cmd.OutOrStdout()) {
+			return report.PlainPalette{}, nil
+		}
+		return report.ANSIPalette{}, nil
+	default:
+		return nil, fmt.Errorf("invalid --color value %q (allowed: auto, always, never)", mode)
+	}
+}
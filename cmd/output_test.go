@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestPrettyFlagIndentsOutput(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func(extra ...string) string {
+		root := newRootCommand()
+		buf := &bytes.Buffer{}
+		root.SetOut(buf)
+		root.SetErr(io.Discard)
+		root.SetArgs(append(extra, "review", "stats", "--repo", "agyn/repo", "51"))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command: %v", err)
+		}
+		return buf.String()
+	}
+
+	compact := run()
+	if strings.Count(compact, "\n") != 1 || strings.Contains(compact, "  ") {
+		t.Fatalf("expected compact single-line output by default, got %q", compact)
+	}
+
+	pretty := run("--pretty")
+	if !strings.Contains(pretty, "\n  \"reviews\": {") {
+		t.Fatalf("expected indented output with --pretty, got %q", pretty)
+	}
+	assertJSONEqual(t, compact, []byte(pretty))
+}
+
+func TestReviewViewTextFormatColor(t *testing.T) {
+	originalFactory := apiClientFactory
+	originalTerminal := isTerminal
+	defer func() {
+		apiClientFactory = originalFactory
+		isTerminal = originalTerminal
+	}()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func(tty bool, noColor string, extra ...string) string {
+		isTerminal = func(io.Writer) bool { return tty }
+		t.Setenv("NO_COLOR", noColor)
+		root := newRootCommand()
+		buf := &bytes.Buffer{}
+		root.SetOut(buf)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, extra...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command: %v", err)
+		}
+		return buf.String()
+	}
+
+	const green = "\x1b[32m"
+
+	if out := run(true, "", "--format", "text"); !strings.Contains(out, green+"APPROVED") {
+		t.Fatalf("expected colored text on a TTY, got %q", out)
+	}
+	if out := run(false, "", "--format", "text"); strings.Contains(out, "\x1b[") || !strings.Contains(out, "APPROVED alice") {
+		t.Fatalf("expected plain text when not a TTY, got %q", out)
+	}
+	if out := run(true, "1", "--format", "text"); strings.Contains(out, "\x1b[") {
+		t.Fatalf("expected NO_COLOR to disable color, got %q", out)
+	}
+	if out := run(false, "1", "--format", "text", "--color", "always"); !strings.Contains(out, green) {
+		t.Fatalf("expected --color always to force color, got %q", out)
+	}
+	if out := run(true, "", "--color", "always"); strings.Contains(out, "\x1b[") || !strings.HasPrefix(out, "{") {
+		t.Fatalf("expected JSON output unaffected by color, got %q", out)
+	}
+}
+
+func TestReviewViewRejectsUnknownFormatAndColor(t *testing.T) {
+	for _, args := range [][]string{{"--format", "yaml"}, {"--color", "sometimes"}} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, args...))
+		err := root.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid "+args[0]) {
+			t.Fatalf("expected invalid %s error, got %v", args[0], err)
+		}
+	}
+}
+
+func TestOutputFileWritesJSONInsteadOfStdout(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stats.json")
+	if err := os.WriteFile(path, []byte("stale"), 0o644); err != nil {
+		t.Fatalf("seed output file: %v", err)
+	}
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--output-file", path, "review", "stats", "--repo", "agyn/repo", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	if stdout.Len() != 0 {
+		t.Fatalf("expected stdout to stay empty, got %q", stdout.String())
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read output file: %v", err)
+	}
+	if !strings.Contains(string(written), `"reviews":`) {
+		t.Fatalf("expected stats JSON in output file, got %q", written)
+	}
+	assertOnlyFile(t, dir, "stats.json")
+}
+
+func TestOutputFileLeavesNoPartialFileOnEncodeError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(path, []byte("previous"), 0o644); err != nil {
+		t.Fatalf("seed output file: %v", err)
+	}
+
+	root := newRootCommand()
+	if err := root.ParseFlags([]string{"--output-file", path}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := encodeJSON(root, map[string]interface{}{"bad": make(chan int)})
+	if err == nil || !strings.Contains(err.Error(), "encode json") {
+		t.Fatalf("expected encode error, got %v", err)
+	}
+
+	kept, readErr := os.ReadFile(path)
+	if readErr != nil || string(kept) != "previous" {
+		t.Fatalf("expected previous file to be untouched, got %q (%v)", kept, readErr)
+	}
+	assertOnlyFile(t, dir, "out.json")
+}
+
+func TestOutputFileUnwritableDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "out.json")
+
+	root := newRootCommand()
+	if err := root.ParseFlags([]string{"--output-file", path}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := encodeJSON(root, map[string]string{"status": "ok"})
+	if err == nil || !strings.Contains(err.Error(), "write --output-file "+path) {
+		t.Fatalf("expected write error naming the path, got %v", err)
+	}
+}
+
+// assertOnlyFile fails unless dir holds exactly the named file, catching
+// temporary files left behind by an interrupted write.
+func assertOnlyFile(t *testing.T, dir, name string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != name {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+		t.Fatalf("expected only %s in %s, got %v", name, dir, names)
+	}
+}
+
+func TestJSONLWritesArrayElementsPerLine(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--pretty", "review", "view", "--jsonl", "--repo", "agyn/repo", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per review, got %q", buf.String())
+	}
+	for i, want := range []string{"R1", "R2"} {
+		var review struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &review); err != nil || review.ID != want {
+			t.Fatalf("line %d: expected review %s, got %q (%v)", i, want, lines[i], err)
+		}
+	}
+}
+
+func TestJSONLWarnsWhenReportTruncated(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"review", "view", "--jsonl", "--max-threads", "1", "--repo", "agyn/repo", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	if strings.Contains(stdout.String(), `"truncated"`) {
+		t.Fatalf("expected review lines without report fields, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "truncated after 1 review threads") {
+		t.Fatalf("expected truncation warning on stderr, got %q", stderr.String())
+	}
+}
+
+func TestJSONLFallsBackForObjects(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("jsonl", true, "")
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+
+	if err := encodeJSON(cmd, map[string]string{"status": "ok"}); err != nil {
+		t.Fatalf("encode object: %v", err)
+	}
+	if buf.String() != "{\"status\":\"ok\"}\n" {
+		t.Fatalf("expected the object on a single line, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := encodeJSON(cmd, []int{1, 2}); err != nil {
+		t.Fatalf("encode array: %v", err)
+	}
+	if buf.String() != "1\n2\n" {
+		t.Fatalf("expected one element per line, got %q", buf.String())
+	}
+}
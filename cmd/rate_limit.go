@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/ratelimit"
+)
+
+func newRateLimitCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rate-limit",
+		Short: "Show the remaining REST, GraphQL, and search API quota (REST + GraphQL)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := ratelimit.NewService(newAPIClient(cmd, defaultHost(cmd))).Status()
+			if err != nil {
+				return err
+			}
+			return encodeJSON(cmd, status)
+		},
+	}
+}
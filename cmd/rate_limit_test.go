@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestRateLimitCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "rate_limit", path)
+		return assignJSON(result, obj{"resources": obj{
+			"core":   obj{"limit": 5000, "used": 4990, "remaining": 10, "reset": 1764756000},
+			"search": obj{"limit": 30, "used": 0, "remaining": 30, "reset": 1764756000},
+		}})
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assignJSON(result, obj{"rateLimit": obj{"limit": 5000, "used": 12, "remaining": 4988, "resetAt": "2025-12-03T10:00:00Z", "cost": 1}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "rate-limit")
+	require.NoError(t, err)
+	assertJSONEqual(t, `{
+  "rest": {"limit": 5000, "used": 4990, "remaining": 10, "reset_at": "2025-12-03T10:00:00Z"},
+  "graphql": {"limit": 5000, "used": 12, "remaining": 4988, "reset_at": "2025-12-03T10:00:00Z", "cost": 1},
+  "search": {"limit": 30, "used": 0, "remaining": 30, "reset_at": "2025-12-03T10:00:00Z"}
+}`, []byte(out))
+}
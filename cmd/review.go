@@ -3,7 +3,6 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,6 +22,7 @@ func newReviewCommand() *cobra.Command {
 			if len(args) > 0 {
 				opts.Selector = args[0]
 			}
+			opts.HasSuggestion = cmd.Flags().Changed("suggestion")
 			return runReview(cmd, opts)
 		},
 	}
@@ -33,7 +33,11 @@ func newReviewCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.Start, "start", false, "Open a pending review")
 	cmd.Flags().BoolVar(&opts.AddComment, "add-comment", false, "Add an inline comment to a pending review")
 	cmd.Flags().BoolVar(&opts.Submit, "submit", false, "Submit a pending review")
+	cmd.Flags().BoolVar(&opts.ValidateOnly, "validate-only", false, "With --add-comment, check that the target line is on the diff without posting")
 
+	cmd.Flags().BoolVar(&opts.ReuseExisting, "reuse-existing", false, "With --start, return your latest pending review instead of opening another")
+	cmd.Flags().BoolVar(&opts.FlushDrafts, "flush-drafts", false, "With --start, post the local drafts saved with 'review draft add' into the review")
+	cmd.Flags().StringVar(&opts.DraftDir, "draft-dir", "", "Directory for local drafts (defaults to $GH_PR_REVIEW_DRAFT_DIR, then the user config directory)")
 	cmd.Flags().StringVar(&opts.Commit, "commit", "", "Commit SHA for review start (defaults to current head)")
 	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID)")
 	cmd.Flags().StringVar(&opts.Path, "path", "", "File path for inline comment")
@@ -42,9 +46,17 @@ func newReviewCommand() *cobra.Command {
 	cmd.Flags().IntVar(&opts.StartLine, "start-line", 0, "Start line for multi-line comments")
 	cmd.Flags().StringVar(&opts.StartSide, "start-side", "", "Start side for multi-line comments")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment or review body")
-	cmd.Flags().StringVar(&opts.Event, "event", opts.Event, "Review submission event (APPROVE, COMMENT, REQUEST_CHANGES)")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read the comment or review body from a file (use \"-\" for stdin)")
+	cmd.Flags().BoolVar(&opts.NormalizeNewlines, "normalize-newlines", false, "Convert CRLF line endings in the body to LF before sending")
+	cmd.Flags().StringVar(&opts.Suggestion, "suggestion", "", "Replacement text wrapped in a suggestion block (--add-comment only; --body becomes the preamble)")
+	cmd.Flags().StringVar(&opts.Event, "event", opts.Event, "Review submission event (APPROVE, COMMENT, REQUEST_CHANGES, or auto)")
+	cmd.Flags().BoolVar(&opts.NoBodyRequired, "no-body-required", false, "Allow submitting COMMENT or REQUEST_CHANGES without a body")
+	cmd.Flags().BoolVar(&opts.AutoRequestChanges, "auto-request-changes", false, "With --event auto, submit REQUEST_CHANGES instead of COMMENT when unresolved threads remain")
 
+	cmd.AddCommand(newReviewDraftCommand())
 	cmd.AddCommand(newReviewViewCommand())
+	cmd.AddCommand(newReviewStatsCommand())
+	cmd.AddCommand(newReviewApproveCommand())
 
 	return cmd
 }
@@ -54,19 +66,32 @@ type reviewOptions struct {
 	Pull     int
 	Selector string
 
-	Start      bool
-	AddComment bool
-	Submit     bool
-
-	Commit    string
-	ReviewID  string
-	Path      string
-	Line      int
-	Side      string
-	StartLine int
-	StartSide string
-	Body      string
-	Event     string
+	Start        bool
+	AddComment   bool
+	Submit       bool
+	ValidateOnly bool
+
+	ReuseExisting bool
+	FlushDrafts   bool
+	DraftDir      string
+
+	Commit            string
+	ReviewID          string
+	Path              string
+	Line              int
+	Side              string
+	StartLine         int
+	StartSide         string
+	Body              string
+	BodyFile          string
+	NormalizeNewlines bool
+	Event             string
+
+	AutoRequestChanges bool
+	NoBodyRequired     bool
+
+	Suggestion    string
+	HasSuggestion bool
 }
 
 func runReview(cmd *cobra.Command, opts *reviewOptions) error {
@@ -80,19 +105,34 @@ func runReview(cmd *cobra.Command, opts *reviewOptions) error {
 	if enabled != 1 {
 		return errors.New("specify exactly one of --start, --add-comment, or --submit")
 	}
+	if opts.AutoRequestChanges && !strings.EqualFold(strings.TrimSpace(opts.Event), "auto") {
+		return errors.New("--auto-request-changes requires --event auto")
+	}
+	if opts.ReuseExisting && !opts.Start {
+		return errors.New("--reuse-existing can only be used with --start")
+	}
+	if opts.FlushDrafts && !opts.Start {
+		return errors.New("--flush-drafts can only be used with --start")
+	}
+	if opts.ValidateOnly && !opts.AddComment {
+		return errors.New("--validate-only can only be used with --add-comment")
+	}
+	if opts.HasSuggestion && !opts.AddComment {
+		return errors.New("--suggestion can only be used with --add-comment")
+	}
 
-	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	body, err := readBody(cmd.InOrStdin(), opts.Body, opts.BodyFile, opts.NormalizeNewlines)
 	if err != nil {
 		return err
 	}
+	opts.Body = body
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
 	if err != nil {
 		return err
 	}
 
-	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	service := reviewsvc.NewService(newAPIClient(cmd, identity.Host))
 
 	switch {
 	case opts.Start:
@@ -105,33 +145,70 @@ func runReview(cmd *cobra.Command, opts *reviewOptions) error {
 }
 
 func executeReviewStart(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
-	state, err := service.Start(pr, strings.TrimSpace(opts.Commit))
+	start := service.Start
+	if opts.ReuseExisting {
+		start = service.StartOrReuse
+	}
+	state, err := start(pr, strings.TrimSpace(opts.Commit))
 	if err != nil {
 		return err
 	}
-	return encodeJSON(cmd, state)
+	if !opts.FlushDrafts {
+		return encodeJSON(cmd, state)
+	}
+
+	flushed, err := flushDrafts(service, pr, state.ID, opts.DraftDir)
+	if flushed == nil {
+		// The review was opened even though no draft could be read; print it
+		// so callers still learn its ID.
+		if encErr := encodeJSON(cmd, state); encErr != nil {
+			return encErr
+		}
+		return err
+	}
+	output := struct {
+		*reviewsvc.ReviewState
+		Drafts *draftFlushResult `json:"drafts"`
+	}{state, flushed}
+	if encErr := encodeJSON(cmd, output); encErr != nil {
+		return encErr
+	}
+	if err != nil {
+		return err
+	}
+	if len(flushed.Failed) > 0 {
+		return fmt.Errorf("%d of %d drafts failed to post and remain in the draft store", len(flushed.Failed), len(flushed.Failed)+len(flushed.Posted))
+	}
+	return nil
 }
 
 func executeReviewAddComment(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
 	reviewID := strings.TrimSpace(opts.ReviewID)
-	if reviewID == "" {
+	if reviewID == "" && !opts.ValidateOnly {
 		return errors.New("--review-id is required")
 	}
-	if !strings.HasPrefix(reviewID, "PRR_") {
+	if reviewID != "" && !strings.HasPrefix(reviewID, "PRR_") {
 		return fmt.Errorf("invalid --review-id %q: must be a GraphQL node id (PRR_...)", opts.ReviewID)
 	}
 
-	side, err := normalizeSide(opts.Side)
+	side, err := reviewsvc.NormalizeSide(opts.Side)
 	if err != nil {
 		return err
 	}
+	body := opts.Body
+	if opts.HasSuggestion {
+		if err := validateSuggestionTarget(opts, side); err != nil {
+			return err
+		}
+		body = buildSuggestionBody(opts.Body, opts.Suggestion)
+	}
 	var startLine *int
 	if opts.StartLine > 0 {
 		startLine = &opts.StartLine
 	}
 	var startSide *string
 	if opts.StartSide != "" {
-		normalized, err := normalizeSide(opts.StartSide)
+		normalized, err := reviewsvc.NormalizeSide(opts.StartSide)
 		if err != nil {
 			return fmt.Errorf("invalid start-side: %w", err)
 		}
@@ -145,7 +222,15 @@ func executeReviewAddComment(cmd *cobra.Command, service *reviewsvc.Service, pr
 		Side:      side,
 		StartLine: startLine,
 		StartSide: startSide,
-		Body:      opts.Body,
+		Body:      body,
+	}
+
+	if opts.ValidateOnly {
+		result, err := service.ValidateCommentTarget(pr, input)
+		if err != nil {
+			return err
+		}
+		return encodeJSON(cmd, result)
 	}
 
 	thread, err := service.AddThread(pr, input)
@@ -156,14 +241,31 @@ func executeReviewAddComment(cmd *cobra.Command, service *reviewsvc.Service, pr
 }
 
 func executeReviewSubmit(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
-	event, err := normalizeEvent(opts.Event)
-	if err != nil {
-		return err
+	auto := strings.EqualFold(strings.TrimSpace(opts.Event), "auto")
+	var event string
+	if !auto {
+		normalized, err := normalizeEvent(opts.Event)
+		if err != nil {
+			return err
+		}
+		event = normalized
 	}
 	reviewID, err := ensureGraphQLReviewID(opts.ReviewID)
 	if err != nil {
 		return err
 	}
+	if auto {
+		decision, err := service.AutoEvent(pr, reviewID, reviewsvc.AutoEventOptions{RequestChanges: opts.AutoRequestChanges})
+		if err != nil {
+			return err
+		}
+		event = decision.Event
+	}
+	if !opts.NoBodyRequired {
+		if err := requireSubmitBody(event, opts.Body); err != nil {
+			return err
+		}
+	}
 	input := reviewsvc.SubmitInput{
 		ReviewID: reviewID,
 		Event:    event,
@@ -174,7 +276,14 @@ func executeReviewSubmit(cmd *cobra.Command, service *reviewsvc.Service, pr reso
 		return err
 	}
 	if status.Success {
-		return encodeJSON(cmd, map[string]string{"status": "Review submitted successfully"})
+		success := map[string]string{"status": "Review submitted successfully"}
+		if auto {
+			success["event"] = event
+		}
+		if status.HTMLURL != "" {
+			success["html_url"] = status.HTMLURL
+		}
+		return encodeJSON(cmd, success)
 	}
 	failure := map[string]interface{}{
 		"status": "Review submission failed",
@@ -188,16 +297,56 @@ func executeReviewSubmit(cmd *cobra.Command, service *reviewsvc.Service, pr reso
 	return errors.New("review submission failed")
 }
 
-func normalizeSide(side string) (string, error) {
-	s := strings.ToUpper(strings.TrimSpace(side))
-	switch s {
-	case "LEFT", "RIGHT":
-		return s, nil
-	case "":
-		return "", errors.New("side is required")
-	default:
-		return "", fmt.Errorf("invalid side %q: must be LEFT or RIGHT", side)
+// requireSubmitBody rejects blank bodies for events GitHub expects to carry an
+// explanation; APPROVE may be submitted without one.
+func requireSubmitBody(event, body string) error {
+	if event == "APPROVE" || strings.TrimSpace(body) != "" {
+		return nil
+	}
+	return fmt.Errorf("--body is required when submitting %s (pass --no-body-required to skip this check)", event)
+}
+
+// validateSuggestionTarget enforces GitHub's rules for suggested changes: they
+// apply to lines on the RIGHT side, and replacing several lines requires
+// --start-line to mark the first line of the range.
+func validateSuggestionTarget(opts *reviewOptions, side string) error {
+	if opts.Line <= 0 {
+		return errors.New("--suggestion requires --line")
+	}
+	if side != "RIGHT" {
+		return errors.New("--suggestion requires --side RIGHT")
+	}
+	if opts.StartLine > 0 && opts.StartLine >= opts.Line {
+		return fmt.Errorf("--suggestion range is invalid: --start-line %d must be less than --line %d", opts.StartLine, opts.Line)
+	}
+	if opts.StartSide != "" && !strings.EqualFold(strings.TrimSpace(opts.StartSide), "RIGHT") {
+		return errors.New("--suggestion requires --start-side RIGHT")
+	}
+	return nil
+}
+
+// buildSuggestionBody wraps replacement text in a suggestion fence, placing any
+// preamble before it. The fence grows when the replacement contains backticks.
+func buildSuggestionBody(preamble, suggestion string) string {
+	fence := "```"
+	for strings.Contains(suggestion, fence) {
+		fence += "`"
+	}
+	suggestion = strings.TrimSuffix(suggestion, "\n")
+
+	var b strings.Builder
+	if trimmed := strings.TrimSpace(preamble); trimmed != "" {
+		b.WriteString(trimmed)
+		b.WriteString("\n\n")
+	}
+	b.WriteString(fence)
+	b.WriteString("suggestion\n")
+	if suggestion != "" {
+		b.WriteString(suggestion)
+		b.WriteString("\n")
 	}
+	b.WriteString(fence)
+	return b.String()
 }
 
 func normalizeEvent(event string) (string, error) {
@@ -206,7 +355,7 @@ func normalizeEvent(event string) (string, error) {
 	case "APPROVE", "COMMENT", "REQUEST_CHANGES":
 		return e, nil
 	default:
-		return "", fmt.Errorf("invalid event %q: must be APPROVE, COMMENT, or REQUEST_CHANGES", event)
+		return "", fmt.Errorf("invalid --event value %q (allowed: APPROVE, COMMENT, REQUEST_CHANGES, auto)", event)
 	}
 }
 
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+func newReviewApproveCommand() *cobra.Command {
+	opts := &reviewApproveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "approve [<number> | <url>]",
+		Short: "Approve a pull request once no threads from others remain unresolved (GraphQL)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewApprove(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Optional approval body")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read the approval body from a file (use \"-\" for stdin)")
+
+	return cmd
+}
+
+type reviewApproveOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Body     string
+	BodyFile string
+}
+
+// runReviewApprove checks for unresolved threads started by other reviewers
+// before touching the review, so a blocked approval leaves no pending review
+// behind. When the pull request is clear it reuses the viewer's pending review
+// (or opens one) and submits it as APPROVE.
+func runReviewApprove(cmd *cobra.Command, opts *reviewApproveOptions) error {
+	body, err := readBody(cmd.InOrStdin(), opts.Body, opts.BodyFile, false)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	api := newAPIClient(cmd, identity.Host)
+	blocking, err := threads.NewService(api).List(identity, threads.ListOptions{
+		OnlyUnresolved: true,
+		OthersOnly:     true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(blocking) > 0 {
+		ids := make([]string, len(blocking))
+		for i, thread := range blocking {
+			ids[i] = thread.ThreadID
+		}
+		return fmt.Errorf("cannot approve: %d unresolved thread(s) from other reviewers: %s", len(blocking), strings.Join(ids, ", "))
+	}
+
+	service := reviewsvc.NewService(api)
+	state, err := service.StartOrReuse(identity, "")
+	if err != nil {
+		return err
+	}
+	status, err := service.Submit(identity, reviewsvc.SubmitInput{
+		ReviewID: state.ID,
+		Event:    "APPROVE",
+		Body:     body,
+	})
+	if err != nil {
+		return err
+	}
+	if !status.Success {
+		failure := map[string]interface{}{"status": "Review submission failed"}
+		if len(status.Errors) > 0 {
+			failure["errors"] = status.Errors
+		}
+		if err := encodeJSON(cmd, failure); err != nil {
+			return err
+		}
+		return errors.New("review submission failed")
+	}
+
+	success := map[string]interface{}{
+		"status":    "Review submitted successfully",
+		"event":     "APPROVE",
+		"review_id": state.ID,
+		"reused":    state.Reused,
+	}
+	if status.HTMLURL != "" {
+		success["html_url"] = status.HTMLURL
+	}
+	return encodeJSON(cmd, success)
+}
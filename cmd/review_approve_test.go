@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// approveFake serves the thread listing from threads and records the event of
+// any submitted review.
+func approveFake(t *testing.T, threads []obj, submitted *string) *commandFakeAPI {
+	t.Helper()
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, obj{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/7":
+			return assignJSON(result, obj{"node_id": "PR_node"})
+		default:
+			return errors.New("unexpected REST path: " + path)
+		}
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "reviewThreads"):
+			return assignJSON(result, obj{"node": obj{"reviewThreads": obj{
+				"nodes":    threads,
+				"pageInfo": obj{"hasNextPage": false},
+			}}})
+		case strings.Contains(query, "ViewerLogin"):
+			return assignJSON(result, obj{"data": obj{"viewer": obj{"login": "casey"}}})
+		case strings.Contains(query, "PendingReviews"):
+			return assignJSON(result, obj{"data": obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+				"nodes": []obj{{
+					"id":         "PRR_existing",
+					"databaseId": 5,
+					"state":      "PENDING",
+					"createdAt":  "2024-06-01T10:00:00Z",
+					"author":     obj{"login": "casey"},
+				}},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}}})
+		case strings.Contains(query, "submitPullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_existing", input["pullRequestReviewId"])
+			*submitted = input["event"].(string)
+			return assignJSON(result, obj{"submitPullRequestReview": obj{"pullRequestReview": obj{
+				"id":  "PRR_existing",
+				"url": "https://github.com/octo/demo/pull/7#pullrequestreview-5",
+			}}})
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", query)
+			return nil
+		}
+	}
+	return fake
+}
+
+func approveThread(id string, resolved, viewerStarted bool) obj {
+	return obj{
+		"id":         id,
+		"isResolved": resolved,
+		"path":       "main.go",
+		"comments": obj{"nodes": []obj{{
+			"viewerDidAuthor": viewerStarted,
+			"createdAt":       "2024-06-01T09:00:00Z",
+			"updatedAt":       "2024-06-01T09:00:00Z",
+		}}},
+	}
+}
+
+func TestReviewApproveCommandSubmitsWhenClear(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var submitted string
+	fake := approveFake(t, []obj{
+		approveThread("PRRT_resolved", true, false),
+		approveThread("PRRT_mine", false, true),
+	}, &submitted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "review", "approve", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.Equal(t, "APPROVE", submitted)
+	assertJSONEqual(t, `{
+  "status": "Review submitted successfully",
+  "event": "APPROVE",
+  "review_id": "PRR_existing",
+  "reused": true,
+  "html_url": "https://github.com/octo/demo/pull/7#pullrequestreview-5"
+}`, []byte(out))
+}
+
+func TestReviewApproveCommandBlockedByUnresolvedThreads(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var submitted string
+	fake := approveFake(t, []obj{
+		approveThread("PRRT_open", false, false),
+		approveThread("PRRT_mine", false, true),
+	}, &submitted)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "review", "approve", "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Equal(t, "cannot approve: 1 unresolved thread(s) from other reviewers: PRRT_open", err.Error())
+	assert.Empty(t, submitted)
+	assert.Empty(t, out)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/draft"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
+)
+
+func newReviewDraftCommand() *cobra.Command {
+	opts := &reviewDraftOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "draft",
+		Short: "Save inline comments locally until a pending review exists",
+	}
+
+	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.PersistentFlags().StringVar(&opts.DraftDir, "draft-dir", "", "Directory for local drafts (defaults to $GH_PR_REVIEW_DRAFT_DIR, then the user config directory)")
+
+	cmd.AddCommand(newReviewDraftAddCommand(opts))
+	cmd.AddCommand(newReviewDraftListCommand(opts))
+
+	return cmd
+}
+
+type reviewDraftOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	DraftDir string
+}
+
+func newReviewDraftAddCommand(parent *reviewDraftOptions) *cobra.Command {
+	opts := &reviewDraftAddOptions{reviewDraftOptions: parent, Side: "RIGHT"}
+
+	cmd := &cobra.Command{
+		Use:   "add [<number> | <url>]",
+		Short: "Save an inline comment as a local draft",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewDraftAdd(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Path, "path", "", "File path for the inline comment")
+	cmd.Flags().IntVar(&opts.Line, "line", 0, "Line number for the inline comment")
+	cmd.Flags().StringVar(&opts.Side, "side", opts.Side, "Diff side for the inline comment (LEFT or RIGHT)")
+	cmd.Flags().IntVar(&opts.StartLine, "start-line", 0, "Start line for multi-line comments")
+	cmd.Flags().StringVar(&opts.StartSide, "start-side", "", "Start side for multi-line comments")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment body")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read the comment body from a file (use \"-\" for stdin)")
+	cmd.Flags().BoolVar(&opts.NormalizeNewlines, "normalize-newlines", false, "Convert CRLF line endings in the body to LF before sending")
+
+	return cmd
+}
+
+type reviewDraftAddOptions struct {
+	*reviewDraftOptions
+
+	Path              string
+	Line              int
+	Side              string
+	StartLine         int
+	StartSide         string
+	Body              string
+	BodyFile          string
+	NormalizeNewlines bool
+}
+
+func runReviewDraftAdd(cmd *cobra.Command, opts *reviewDraftAddOptions) error {
+	body, err := readBody(cmd.InOrStdin(), opts.Body, opts.BodyFile, opts.NormalizeNewlines)
+	if err != nil {
+		return err
+	}
+	side, err := reviewsvc.NormalizeSide(opts.Side)
+	if err != nil {
+		return err
+	}
+	entry := draft.Draft{
+		Path:      strings.TrimSpace(opts.Path),
+		Line:      opts.Line,
+		Side:      side,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+	}
+	if opts.StartLine > 0 {
+		startLine := opts.StartLine
+		entry.StartLine = &startLine
+	}
+	if opts.StartSide != "" {
+		normalized, err := reviewsvc.NormalizeSide(opts.StartSide)
+		if err != nil {
+			return fmt.Errorf("invalid start-side: %w", err)
+		}
+		entry.StartSide = &normalized
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+	store, err := draft.NewStore(opts.DraftDir)
+	if err != nil {
+		return err
+	}
+	if _, err := store.Add(identity, entry); err != nil {
+		return err
+	}
+	return encodeJSON(cmd, entry)
+}
+
+func newReviewDraftListCommand(opts *reviewDraftOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [<number> | <url>]",
+		Short: "List local drafts waiting for a pending review",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+			if err != nil {
+				return err
+			}
+			store, err := draft.NewStore(opts.DraftDir)
+			if err != nil {
+				return err
+			}
+			drafts, err := store.List(identity)
+			if err != nil {
+				return err
+			}
+			return encodeJSON(cmd, drafts)
+		},
+	}
+}
+
+// draftFlushResult is added to the --start output with --flush-drafts.
+type draftFlushResult struct {
+	Posted []reviewsvc.ReviewThread `json:"posted"`
+	Failed []draftFailure           `json:"failed,omitempty"`
+}
+
+// draftFailure is a draft that could not be posted; it stays in the store.
+type draftFailure struct {
+	draft.Draft
+	Error string `json:"error"`
+}
+
+// flushDrafts posts the stored drafts for pr into reviewID.
+func flushDrafts(service *reviewsvc.Service, pr resolver.Identity, reviewID, dir string) (*draftFlushResult, error) {
+	store, err := draft.NewStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &draftFlushResult{Posted: []reviewsvc.ReviewThread{}}
+	outcomes, err := store.Flush(pr, func(d draft.Draft) error {
+		thread, err := service.AddThread(pr, reviewsvc.ThreadInput{
+			ReviewID:  reviewID,
+			Path:      d.Path,
+			Line:      d.Line,
+			Side:      d.Side,
+			StartLine: d.StartLine,
+			StartSide: d.StartSide,
+			Body:      d.Body,
+		})
+		if err != nil {
+			return err
+		}
+		result.Posted = append(result.Posted, *thread)
+		return nil
+	})
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			result.Failed = append(result.Failed, draftFailure{Draft: outcome.Draft, Error: outcome.Err.Error()})
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func runDraftCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs(args)
+	err := root.Execute()
+	return stdout.String(), err
+}
+
+func TestReviewDraftAddListAndFlush(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	dir := t.TempDir()
+	_, err := runDraftCommand(t, "review", "draft", "add", "--draft-dir", dir, "--path", "main.go", "--line", "4", "--body", "Handle the error", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	_, err = runDraftCommand(t, "review", "draft", "add", "--draft-dir", dir, "--path", "gone.go", "--line", "9", "--side", "left", "--body", "Stale", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+
+	listed, err := runDraftCommand(t, "review", "draft", "list", "--draft-dir", dir, "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.Contains(t, listed, `"path":"main.go"`)
+	assert.Contains(t, listed, `"side":"LEFT"`)
+
+	var posted []string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			return assignJSON(result, obj{"data": obj{"viewer": obj{"login": "casey"}}})
+		case strings.Contains(query, "PendingReviews"):
+			return assignJSON(result, obj{"data": obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+				"nodes": []obj{{
+					"id":         "PRR_existing",
+					"databaseId": 5,
+					"state":      "PENDING",
+					"createdAt":  "2024-06-01T10:00:00Z",
+					"author":     obj{"login": "casey"},
+				}},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}}})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_existing", input["pullRequestReviewId"])
+			path := input["path"].(string)
+			if path == "gone.go" {
+				return errors.New("line could not be resolved")
+			}
+			posted = append(posted, path)
+			return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{
+				"id": "PRRT_new", "path": path, "isOutdated": false, "line": input["line"],
+			}}})
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "review", "--start", "--reuse-existing", "--flush-drafts", "--draft-dir", dir, "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 drafts failed to post")
+	assert.Equal(t, []string{"main.go"}, posted)
+
+	var result struct {
+		ID     string `json:"id"`
+		Reused bool   `json:"reused"`
+		Drafts struct {
+			Posted []struct {
+				ID   string `json:"id"`
+				Path string `json:"path"`
+			} `json:"posted"`
+			Failed []struct {
+				Path  string `json:"path"`
+				Side  string `json:"side"`
+				Error string `json:"error"`
+			} `json:"failed"`
+		} `json:"drafts"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "PRR_existing", result.ID)
+	assert.True(t, result.Reused)
+	require.Len(t, result.Drafts.Posted, 1)
+	assert.Equal(t, "main.go", result.Drafts.Posted[0].Path)
+	require.Len(t, result.Drafts.Failed, 1)
+	assert.Equal(t, "gone.go", result.Drafts.Failed[0].Path)
+	assert.Equal(t, "LEFT", result.Drafts.Failed[0].Side)
+	assert.Equal(t, "line could not be resolved", result.Drafts.Failed[0].Error)
+
+	remaining, err := runDraftCommand(t, "review", "draft", "list", "--draft-dir", dir, "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.NotContains(t, remaining, "main.go")
+	assert.Contains(t, remaining, "gone.go")
+}
+
+func TestReviewFlushDraftsPrintsReviewWhenStoreUnavailable(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	t.Setenv("GH_PR_REVIEW_DRAFT_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_node", "headRefOid": "abc123"}}})
+		case strings.Contains(query, "addPullRequestReview("):
+			return assignJSON(result, obj{"addPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_new", "state": "PENDING"}}})
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "review", "--start", "--flush-drafts", "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "locate draft directory")
+	assert.Contains(t, out, `"id":"PRR_new"`)
+}
+
+func TestReviewFlushDraftsRequiresStart(t *testing.T) {
+	_, err := runDraftCommand(t, "review", "--submit", "--flush-drafts", "--review-id", "PRR_kwM123", "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--flush-drafts can only be used with --start")
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func newReviewStatsCommand() *cobra.Command {
+	opts := &reviewStatsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats [<number> | <url>]",
+		Short: "Summarize review, thread, and comment counts (GraphQL)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewStats(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringSliceVar(&opts.Reviewers, "reviewer", nil, "Filter to reviewers by login (comma-separated or repeated; @me for yourself)")
+	cmd.Flags().StringSliceVar(&opts.States, "states", nil, "Comma-separated review states (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING)")
+	cmd.Flags().BoolVar(&opts.Unresolved, "unresolved", false, "Only count unresolved threads")
+	cmd.Flags().BoolVar(&opts.NotOutdated, "not_outdated", false, "Exclude outdated threads")
+	cmd.Flags().BoolVar(&opts.ReportCost, "report-cost", false, "Print the GraphQL rate limit cost of the report queries to stderr")
+	cmd.Flags().BoolVar(&opts.AllowGhostAuthors, "allow-ghost-authors", false, "Count reviews and comments from deleted accounts under \"ghost\" instead of failing")
+
+	return cmd
+}
+
+type reviewStatsOptions struct {
+	Repo        string
+	Pull        int
+	Selector    string
+	Reviewers   []string
+	States      []string
+	Unresolved  bool
+	NotOutdated bool
+
+	AllowGhostAuthors bool
+	ReportCost        bool
+}
+
+func runReviewStats(cmd *cobra.Command, opts *reviewStatsOptions) error {
+	states, statesProvided, err := parseStateFilters(opts.States)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	api := newAPIClient(cmd, identity.Host)
+	reviewers, err := expandMeLogins(api, opts.Reviewers)
+	if err != nil {
+		return err
+	}
+
+	service := report.NewService(api)
+	output, err := service.Fetch(identity, report.Options{
+		Reviewers:          reviewers,
+		States:             states,
+		StatesProvided:     statesProvided,
+		RequireUnresolved:  opts.Unresolved,
+		RequireNotOutdated: opts.NotOutdated,
+		AllowGhostAuthors:  opts.AllowGhostAuthors,
+		ReportCost:         opts.ReportCost,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := encodeJSON(cmd, report.Summarize(output)); err != nil {
+		return err
+	}
+	if output.Cost != nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), costNote(output.Cost))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestReviewStatsCommandSummarizesReport(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "stats", "--repo", "agyn/repo", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	want := `{"reviews":{"APPROVED":1,"COMMENTED":1},"threads":{"resolved":1,"unresolved":1,"outdated":1},"comments_total":5,"reviewers":["alice","bob"]}`
+	assertJSONEqual(t, want, buf.Bytes())
+}
+
+func TestReviewStatsCommandRespectsReviewerFilter(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "stats", "--repo", "agyn/repo", "--reviewer", "alice", "--states", "APPROVED", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	want := `{"reviews":{"APPROVED":1},"threads":{"resolved":0,"unresolved":1,"outdated":0},"comments_total":3,"reviewers":["alice"]}`
+	assertJSONEqual(t, want, buf.Bytes())
+	if _, ok := fake.variables["states"]; !ok {
+		t.Fatalf("expected states variable propagated, got %#v", fake.variables)
+	}
+}
+
+func TestReviewStatsCommandAcceptsMultipleReviewers(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	for _, args := range [][]string{
+		{"--reviewer", "alice,BOB"},
+		{"--reviewer", "alice", "--reviewer", "bob"},
+	} {
+		root := newRootCommand()
+		buf := &bytes.Buffer{}
+		root.SetOut(buf)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "stats", "--repo", "agyn/repo", "51"}, args...))
+
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command %v: %v", args, err)
+		}
+
+		var payload struct {
+			Reviewers []string `json:"reviewers"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("parse json: %v", err)
+		}
+		if len(payload.Reviewers) != 2 {
+			t.Fatalf("expected both reviewers for %v, got %v", args, payload.Reviewers)
+		}
+	}
+}
+
+func assertJSONEqual(t *testing.T, want string, got []byte) {
+	t.Helper()
+	var wantValue, gotValue interface{}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("parse expected json: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("parse output json: %v", err)
+	}
+	wantBytes, _ := json.Marshal(wantValue)
+	gotBytes, _ := json.Marshal(gotValue)
+	if string(wantBytes) != string(gotBytes) {
+		t.Fatalf("unexpected output:\n got %s\nwant %s", gotBytes, wantBytes)
+	}
+}
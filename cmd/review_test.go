@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/agynio/gh-pr-review/internal/ghcli"
@@ -72,6 +74,54 @@ func TestReviewStartCommand_GraphQLOnly(t *testing.T) {
 	assert.Equal(t, 2, call)
 }
 
+func TestReviewStartCommandReuseExisting(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			return assignJSON(result, obj{"data": obj{"viewer": obj{"login": "casey"}}})
+		case strings.Contains(query, "PendingReviews"):
+			return assignJSON(result, obj{"data": obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+				"nodes": []obj{{
+					"id":         "PRR_existing",
+					"databaseId": 5,
+					"state":      "PENDING",
+					"createdAt":  "2024-06-01T10:00:00Z",
+					"author":     obj{"login": "casey"},
+				}},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}}})
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--start", "--reuse-existing", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assertJSONEqual(t, `{"id":"PRR_existing","state":"PENDING","reused":true}`, stdout.Bytes())
+}
+
+func TestReviewReuseExistingRequiresStart(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--submit", "--reuse-existing", "--review-id", "PRR_kwM123", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--reuse-existing can only be used with --start")
+}
+
 func TestReviewAddCommentCommand_GraphQLOnly(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -119,6 +169,38 @@ func TestReviewAddCommentCommand_GraphQLOnly(t *testing.T) {
 	assert.Equal(t, float64(12), payload["line"])
 }
 
+func TestReviewAddCommentValidateOnly(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "repos/octo/demo/pulls/7/files", path)
+		return assignJSON(result, []obj{{"filename": "scenario.md", "patch": "@@ -10,2 +10,3 @@\n line\n+added\n line"}})
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		t.Fatalf("--validate-only must not call GraphQL: %s", query)
+		return nil
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	for _, tc := range []struct {
+		line string
+		want string
+	}{
+		{line: "11", want: `{"valid":true}`},
+		{line: "30", want: `{"valid":false,"reason":"line 30 (RIGHT) is not part of the diff for scenario.md"}`},
+	} {
+		out, err := runDraftCommand(t, "review", "--add-comment", "--validate-only", "--path", "scenario.md", "--line", tc.line, "--repo", "octo/demo", "7")
+		require.NoError(t, err)
+		assertJSONEqual(t, tc.want, []byte(out))
+	}
+
+	_, err := runDraftCommand(t, "review", "--start", "--validate-only", "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--validate-only can only be used with --add-comment")
+}
+
 func TestReviewAddCommentCommandRequiresGraphQLReviewID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -178,6 +260,149 @@ func TestReviewSubmitCommand(t *testing.T) {
 	assert.Equal(t, "Review submitted successfully", payload["status"])
 }
 
+func TestReviewSubmitCommandRejectsUnknownEvent(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return errors.New("unexpected GraphQL call")
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "APPROVED", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, `invalid --event value "APPROVED" (allowed: APPROVE, COMMENT, REQUEST_CHANGES, auto)`, err.Error())
+}
+
+func TestReviewSubmitCommandNormalizesEventCase(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var submitted interface{}
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		payload, ok := variables["input"].(map[string]interface{})
+		require.True(t, ok)
+		submitted = payload["event"]
+		return assignJSON(result, obj{})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", " request_changes ", "--body", "Needs work", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "REQUEST_CHANGES", submitted)
+}
+
+func TestReviewSubmitCommandAutoEvent(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var submitted string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "PendingReviewThreads") {
+			return assignJSON(result, obj{
+				"repository": obj{"pullRequest": obj{"reviewThreads": obj{
+					"nodes": []obj{{
+						"isResolved": false,
+						"comments":   obj{"nodes": []obj{{"pullRequestReview": obj{"id": "PRR_kwM123"}}}},
+					}},
+					"pageInfo": obj{"hasNextPage": false},
+				}}},
+			})
+		}
+		require.Contains(t, query, "submitPullRequestReview")
+		payload, ok := variables["input"].(map[string]interface{})
+		require.True(t, ok)
+		submitted, _ = payload["event"].(string)
+		return assignJSON(result, obj{})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "auto", "--auto-request-changes", "--body", "See comments", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "REQUEST_CHANGES", submitted)
+	assertJSONEqual(t, `{"status":"Review submitted successfully","event":"REQUEST_CHANGES"}`, stdout.Bytes())
+}
+
+func TestReviewSubmitCommandAutoRequestChangesRequiresAutoEvent(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "COMMENT", "--auto-request-changes", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--auto-request-changes requires --event auto")
+}
+
+func TestReviewSubmitCommandBodyRequirement(t *testing.T) {
+	cases := []struct {
+		event   string
+		body    string
+		extra   []string
+		wantErr bool
+	}{
+		{event: "APPROVE"},
+		{event: "APPROVE", body: "LGTM"},
+		{event: "COMMENT", wantErr: true},
+		{event: "COMMENT", body: "  ", wantErr: true},
+		{event: "COMMENT", body: "Notes inline"},
+		{event: "REQUEST_CHANGES", wantErr: true},
+		{event: "REQUEST_CHANGES", body: "Please fix"},
+		{event: "REQUEST_CHANGES", extra: []string{"--no-body-required"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.event+"/"+tc.body, func(t *testing.T) {
+			originalFactory := apiClientFactory
+			defer func() { apiClientFactory = originalFactory }()
+
+			called := false
+			fake := &commandFakeAPI{}
+			fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				called = true
+				return assignJSON(result, obj{})
+			}
+			apiClientFactory = func(host string) ghcli.API { return fake }
+
+			args := []string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", tc.event, "--repo", "octo/demo", "7"}
+			if tc.body != "" {
+				args = append(args, "--body", tc.body)
+			}
+			root := newRootCommand()
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			root.SetArgs(append(args, tc.extra...))
+
+			err := root.Execute()
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "--body is required when submitting "+tc.event)
+				assert.False(t, called, "API must not be called when the body is missing")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
 func TestReviewSubmitCommandRequiresGraphQLReviewID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -243,7 +468,7 @@ func TestReviewSubmitCommandAllowsNullReview(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	root.SetOut(stdout)
 	root.SetErr(&bytes.Buffer{})
-	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "COMMENT", "--repo", "octo/demo", "7"})
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "COMMENT", "--body", "Thanks", "--repo", "octo/demo", "7"})
 
 	err := root.Execute()
 	require.NoError(t, err)
@@ -266,7 +491,7 @@ func TestReviewSubmitCommandHandlesGraphQLErrors(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	root.SetOut(stdout)
 	root.SetErr(&bytes.Buffer{})
-	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "COMMENT", "--repo", "octo/demo", "7"})
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_kwM123", "--event", "COMMENT", "--body", "Thanks", "--repo", "octo/demo", "7"})
 
 	err := root.Execute()
 	require.Error(t, err)
@@ -281,3 +506,94 @@ func TestReviewSubmitCommandHandlesGraphQLErrors(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "mutation failed", first["message"])
 }
+
+func TestReviewAddCommentSuggestionBodies(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "single line",
+			args: []string{"--line", "12", "--suggestion", "return nil"},
+			want: "```suggestion\nreturn nil\n```",
+		},
+		{
+			name: "range with preamble",
+			args: []string{"--start-line", "10", "--line", "12", "--body", "Simplify:", "--suggestion", "if err != nil {\n\treturn err\n}"},
+			want: "Simplify:\n\n```suggestion\nif err != nil {\n\treturn err\n}\n```",
+		},
+		{
+			name: "empty suggestion deletes lines",
+			args: []string{"--line", "12", "--suggestion", ""},
+			want: "```suggestion\n```",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalFactory := apiClientFactory
+			defer func() { apiClientFactory = originalFactory }()
+
+			var posted string
+			fake := &commandFakeAPI{}
+			fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				input, ok := variables["input"].(map[string]interface{})
+				require.True(t, ok)
+				posted, _ = input["body"].(string)
+				return assignJSON(result, map[string]interface{}{
+					"addPullRequestReviewThread": map[string]interface{}{
+						"thread": map[string]interface{}{"id": "THREAD1", "path": "scenario.md", "isOutdated": false, "line": 12},
+					},
+				})
+			}
+			apiClientFactory = func(host string) ghcli.API { return fake }
+
+			root := newRootCommand()
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			args := []string{"review", "--add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--repo", "octo/demo", "7"}
+			root.SetArgs(append(args, tc.args...))
+
+			require.NoError(t, root.Execute())
+			assert.Equal(t, tc.want, posted)
+		})
+	}
+}
+
+func TestBuildSuggestionBodyExtendsFence(t *testing.T) {
+	body := buildSuggestionBody("", "```go\nx := 1\n```\n")
+	assert.Equal(t, "````suggestion\n```go\nx := 1\n```\n````", body)
+}
+
+func TestReviewAddCommentSuggestionValidation(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"--line", "12", "--side", "LEFT", "--suggestion", "x"}, want: "--side RIGHT"},
+		{args: []string{"--start-line", "12", "--line", "12", "--suggestion", "x"}, want: "--start-line 12 must be less than --line 12"},
+		{args: []string{"--start-line", "10", "--start-side", "LEFT", "--line", "12", "--suggestion", "x"}, want: "--start-side RIGHT"},
+		{args: []string{"--suggestion", "x"}, want: "--suggestion requires --line"},
+	}
+
+	for _, tc := range cases {
+		root := newRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		args := []string{"review", "--add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--repo", "octo/demo", "7"}
+		root.SetArgs(append(args, tc.args...))
+
+		err := root.Execute()
+		require.Error(t, err, tc.args)
+		assert.Contains(t, err.Error(), tc.want)
+	}
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "--submit", "--review-id", "PRR_review", "--suggestion", "x", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--suggestion can only be used with --add-comment")
+}
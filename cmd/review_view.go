@@ -1,24 +1,30 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/pathglob"
 	"github.com/agynio/gh-pr-review/internal/report"
-	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
 )
 
 func newReviewViewCommand() *cobra.Command {
 	opts := &reviewViewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "view [<number> | <url>]",
-		Short: "View a structured review summary (GraphQL)",
-		Args:  cobra.MaximumNArgs(1),
+		Use:     "view [<number> | <url>]",
+		Aliases: []string{"report"},
+		Short:   "View a structured review summary (GraphQL)",
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				opts.Selector = args[0]
@@ -29,63 +35,396 @@ func newReviewViewCommand() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
-	cmd.Flags().StringVar(&opts.Reviewer, "reviewer", "", "Filter to a specific reviewer (login)")
-	cmd.Flags().StringSliceVar(&opts.States, "states", nil, "Comma-separated review states (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED)")
+	cmd.Flags().StringSliceVar(&opts.Reviewers, "reviewer", nil, "Filter to reviewers by login (comma-separated or repeated; @me for yourself)")
+	cmd.Flags().StringSliceVar(&opts.ExcludeReviewers, "reviewer-exclude", nil, "Drop reviews and replies by these logins (comma-separated or repeated; @me for yourself)")
+	cmd.Flags().StringVar(&opts.ReviewerTeam, "reviewer-team", "", "Filter to reviewers who belong to the team (org/team-slug; needs read:org); narrows --reviewer")
+	cmd.Flags().StringSliceVar(&opts.States, "states", nil, "Comma-separated review states (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING)")
+	cmd.Flags().StringSliceVar(&opts.ExcludeStates, "exclude-states", nil, "Comma-separated review states to drop after --states is applied")
+	cmd.Flags().BoolVar(&opts.IncludeMyPending, "include-my-pending", false, "Also include your own pending review and its comments, marked pending")
+	cmd.Flags().BoolVar(&opts.Concise, "concise", false, "Print only each review's id, state, author, and comment count")
+	cmd.Flags().BoolVar(&opts.FileSummary, "file-summary", false, "Print a per-file rollup of comment and unresolved thread counts and reviewers")
+	cmd.Flags().BoolVar(&opts.CollapseResolved, "collapse-resolved", false, "Replace resolved threads with compact stubs under collapsed_threads")
+	cmd.Flags().BoolVar(&opts.ReportCost, "report-cost", false, "Print the GraphQL rate limit cost of the report queries to stderr")
+	cmd.Flags().BoolVar(&opts.AllowGhostAuthors, "allow-ghost-authors", false, "Attribute reviews and comments from deleted accounts to \"ghost\" instead of failing")
+	cmd.Flags().BoolVar(&opts.DismissedOnly, "dismissed-only", false, "Only include dismissed reviews (same as --states DISMISSED)")
 	cmd.Flags().BoolVar(&opts.Unresolved, "unresolved", false, "Only include unresolved threads")
 	cmd.Flags().BoolVar(&opts.NotOutdated, "not_outdated", false, "Exclude outdated threads")
+	cmd.Flags().BoolVar(&opts.OutdatedOnly, "outdated-only", false, "Only include outdated threads (exclusive with --not_outdated)")
+	cmd.Flags().StringVar(&opts.SinceCommit, "since-commit", "", "Add outdated_relative to comments, measured against this commit of the pull request")
+	cmd.Flags().BoolVar(&opts.Mine, "mine", false, "Only include threads you commented in")
+	cmd.Flags().BoolVar(&opts.OnlyUnreplied, "only-unreplied", false, "Only include unresolved threads whose latest comment is not yours")
+	cmd.Flags().StringVar(&opts.ResolvedBy, "resolved-by", "", "Only include resolved threads resolved by this login")
 	cmd.Flags().IntVar(&opts.TailReplies, "tail", 0, "Limit to the last N replies per thread (0 = all)")
+	cmd.Flags().BoolVar(&opts.DedupReplies, "dedup-replies", false, "Collapse consecutive identical replies within a thread into one with a repeated count")
+	cmd.Flags().IntVar(&opts.HeadReplies, "head-replies", 0, "Limit to the first N replies per thread (0 = all; exclusive with --tail)")
 	cmd.Flags().BoolVar(&opts.IncludeCommentNodeID, "include-comment-node-id", false, "Include comment_node_id fields for parent comments and replies")
+	cmd.Flags().BoolVar(&opts.IncludeDiffHunk, "include-diff-hunk", false, "Include the diff_hunk context for parent comments")
+	cmd.Flags().BoolVar(&opts.IncludeAuthorID, "include-author-id", false, "Include the numeric GitHub user ID (author_id) for reviews, comments, and replies")
+	cmd.Flags().IntVar(&opts.MaxBodyLength, "max-body-length", 0, "Truncate review, comment, and reply bodies to N characters, marking them truncated (0 = no limit)")
+	cmd.Flags().IntVar(&opts.ContextLines, "context-lines", 0, "Attach up to N diff lines around each comment's line as context (0 = off)")
+	cmd.Flags().StringVar(&opts.Order, "order", string(report.OrderChronological), "Order of comments within each review (chronological or path)")
+	cmd.Flags().IntVar(&opts.MaxThreads, "max-threads", 0, "Stop collecting after N review threads and mark the report truncated (0 = unlimited)")
+	cmd.Flags().IntVar(&opts.PerPage, "per-page", report.MaxPerPage, "GraphQL page size for review threads (1-100); reviews and thread comments always use 100")
+	cmd.Flags().IntVar(&opts.MaxPages, "max-pages", 0, "Stop after N pages of review threads and mark the report truncated (0 = unlimited)")
+	cmd.Flags().StringVar(&opts.MinSeverity, "min-severity", "", "Drop comments tagged below this severity (nit, suggestion, warning, blocker)")
+	cmd.Flags().BoolVar(&opts.DropUnlabeled, "drop-unlabeled", false, "Drop comments without a leading [severity] tag")
+	cmd.Flags().StringArrayVar(&opts.Paths, "path", nil, "Only include comments on files matching the glob (repeatable; ** matches directories)")
+	cmd.Flags().StringVar(&opts.LineRange, "line-range", "", "Only include comments anchored within START:END (inclusive)")
+	cmd.Flags().StringVar(&opts.NewSince, "new-since", "", "Only include comments created at or after this RFC3339 timestamp")
+	cmd.Flags().BoolVar(&opts.Web, "web", false, "Open the pull request in a browser after printing the report")
+	cmd.Flags().BoolVar(&opts.WebOnly, "web-only", false, "Open the pull request in a browser without printing the report")
+	cmd.Flags().BoolVar(&opts.FailOnChangesRequested, "fail-on-changes-requested", false, "Exit with status 3 after printing if any review in the report requests changes")
+	cmd.Flags().BoolVar(&opts.FailOnUnresolved, "fail-on-unresolved", false, "Exit with status 3 after printing if any thread in the report is unresolved")
+	cmd.Flags().StringVar(&opts.GroupBy, "group-by", "", "Regroup the JSON report by reviewer (reviewer)")
+	cmd.Flags().StringVar(&opts.Format, "format", "json", "Output format (json or text)")
+	cmd.Flags().Bool("jsonl", false, "Print each review as its own compact JSON line instead of one report object")
+	cmd.Flags().StringVar(&opts.Color, "color", "auto", "Color text output (auto, always, never); auto honors NO_COLOR and TTY detection")
+	cmd.Flags().BoolVar(&opts.NoSchemaVersion, "no-schema-version", false, "Omit the top-level schema_version field")
+	cmd.Flags().BoolVar(&opts.WithMeta, "with-meta", false, "Include a meta block with generation time, tool version, and pull request")
 
 	return cmd
 }
 
 type reviewViewOptions struct {
-	Repo                 string
-	Pull                 int
-	Selector             string
-	Reviewer             string
-	States               []string
-	Unresolved           bool
-	NotOutdated          bool
-	TailReplies          int
-	IncludeCommentNodeID bool
+	Repo                   string
+	Pull                   int
+	Selector               string
+	Reviewers              []string
+	ExcludeReviewers       []string
+	ReviewerTeam           string
+	States                 []string
+	ExcludeStates          []string
+	DismissedOnly          bool
+	Unresolved             bool
+	NotOutdated            bool
+	OutdatedOnly           bool
+	TailReplies            int
+	HeadReplies            int
+	IncludeCommentNodeID   bool
+	IncludeDiffHunk        bool
+	IncludeAuthorID        bool
+	ContextLines           int
+	WithMeta               bool
+	Order                  string
+	MaxThreads             int
+	PerPage                int
+	MaxPages               int
+	MinSeverity            string
+	DropUnlabeled          bool
+	Paths                  []string
+	LineRange              string
+	NewSince               string
+	Web                    bool
+	WebOnly                bool
+	NoSchemaVersion        bool
+	GroupBy                string
+	FailOnChangesRequested bool
+	FailOnUnresolved       bool
+	Format                 string
+	Color                  string
+	ResolvedBy             string
+	Mine                   bool
+	OnlyUnreplied          bool
+	Concise                bool
+	DedupReplies           bool
+	FileSummary            bool
+	MaxBodyLength          int
+	IncludeMyPending       bool
+	AllowGhostAuthors      bool
+	ReportCost             bool
+	CollapseResolved       bool
+	SinceCommit            string
+}
+
+// fileSummaryResult is the --file-summary output.
+type fileSummaryResult struct {
+	Files []report.FileStat `json:"files"`
 }
 
 func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
 	if opts.TailReplies < 0 {
 		return fmt.Errorf("invalid --tail value %d: must be non-negative", opts.TailReplies)
 	}
+	if opts.HeadReplies < 0 {
+		return fmt.Errorf("invalid --head-replies value %d: must be non-negative", opts.HeadReplies)
+	}
+	if opts.TailReplies > 0 && opts.HeadReplies > 0 {
+		return errors.New("--head-replies cannot be combined with --tail")
+	}
+	if opts.MaxBodyLength < 0 {
+		return fmt.Errorf("invalid --max-body-length value %d: must be non-negative", opts.MaxBodyLength)
+	}
+	if opts.ContextLines < 0 {
+		return fmt.Errorf("invalid --context-lines value %d: must be non-negative", opts.ContextLines)
+	}
+	if opts.MaxThreads < 0 {
+		return fmt.Errorf("invalid --max-threads value %d: must be non-negative", opts.MaxThreads)
+	}
+	if opts.PerPage < 1 || opts.PerPage > report.MaxPerPage {
+		return fmt.Errorf("invalid --per-page value %d: must be between 1 and %d", opts.PerPage, report.MaxPerPage)
+	}
+	if opts.MaxPages < 0 {
+		return fmt.Errorf("invalid --max-pages value %d: must be non-negative", opts.MaxPages)
+	}
+
+	if opts.DismissedOnly {
+		if len(opts.States) > 0 {
+			return errors.New("--dismissed-only cannot be combined with --states")
+		}
+		opts.States = []string{string(report.StateDismissed)}
+	}
+	states, statesProvided, err := parseStateFilters(opts.States)
+	if err != nil {
+		return err
+	}
+	excludeStates, _, err := parseStateFilters(opts.ExcludeStates)
+	if err != nil {
+		return err
+	}
+	for _, excluded := range excludeStates {
+		if opts.IncludeMyPending && excluded == report.StatePending {
+			return errors.New("--include-my-pending cannot be combined with --exclude-states PENDING")
+		}
+		for _, included := range states {
+			if excluded == included {
+				return fmt.Errorf("review state %s cannot appear in both --states and --exclude-states", excluded)
+			}
+		}
+	}
 
-	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	order, err := parseCommentOrder(opts.Order)
 	if err != nil {
 		return err
 	}
 
-	states, statesProvided, err := parseStateFilters(opts.States)
+	if opts.NotOutdated && opts.OutdatedOnly {
+		return errors.New("--outdated-only cannot be combined with --not_outdated")
+	}
+
+	if opts.Unresolved && strings.TrimSpace(opts.ResolvedBy) != "" {
+		return errors.New("--resolved-by cannot be combined with --unresolved")
+	}
+
+	if opts.OnlyUnreplied && strings.TrimSpace(opts.ResolvedBy) != "" {
+		return errors.New("--resolved-by cannot be combined with --only-unreplied")
+	}
+
+	sinceCommit := strings.TrimSpace(opts.SinceCommit)
+	if sinceCommit != "" && !commitSHARE.MatchString(sinceCommit) {
+		return fmt.Errorf("invalid --since-commit %q: must be a commit SHA of at least 7 hex characters", opts.SinceCommit)
+	}
+
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	if format != "json" && format != "text" {
+		return fmt.Errorf("invalid --format value %q (allowed: json, text)", opts.Format)
+	}
+	groupBy := strings.ToLower(strings.TrimSpace(opts.GroupBy))
+	if groupBy != "" && groupBy != "reviewer" {
+		return fmt.Errorf("invalid --group-by value %q (allowed: reviewer)", opts.GroupBy)
+	}
+	if groupBy != "" && format == "text" {
+		return errors.New("--group-by cannot be combined with --format text")
+	}
+	if opts.Concise && format == "text" {
+		return errors.New("--concise cannot be combined with --format text")
+	}
+	if opts.Concise && groupBy != "" {
+		return errors.New("--concise cannot be combined with --group-by")
+	}
+	if opts.FileSummary && format == "text" {
+		return errors.New("--file-summary cannot be combined with --format text")
+	}
+	if opts.FileSummary && groupBy != "" {
+		return errors.New("--file-summary cannot be combined with --group-by")
+	}
+	if opts.FileSummary && opts.Concise {
+		return errors.New("--file-summary cannot be combined with --concise")
+	}
+	if format == "text" && outputFile(cmd) != "" {
+		return errors.New("--output-file cannot be combined with --format text")
+	}
+	if format == "text" && persistentBool(cmd, "jsonl") {
+		return errors.New("--jsonl cannot be combined with --format text")
+	}
+	palette, err := textPalette(cmd, opts.Color)
+	if err != nil {
+		return err
+	}
+
+	minSeverity, err := parseMinSeverity(opts.MinSeverity)
+	if err != nil {
+		return err
+	}
+
+	for _, pattern := range opts.Paths {
+		if err := pathglob.Validate(pattern); err != nil {
+			return fmt.Errorf("invalid --path glob %q: %w", pattern, err)
+		}
+	}
+
+	lineRange, err := parseLineRange(opts.LineRange)
+	if err != nil {
+		return err
+	}
+
+	var newSince *time.Time
+	if raw := strings.TrimSpace(opts.NewSince); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("invalid --new-since value %q: expected an RFC3339 timestamp such as 2025-12-03T10:00:00Z", opts.NewSince)
+		}
+		newSince = &parsed
+	}
+
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
 	if err != nil {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	if opts.WebOnly {
+		return openBrowser(identity.URL())
+	}
+
+	api := newAPIClient(cmd, identity.Host)
+	reviewers, err := expandMeLogins(api, opts.Reviewers)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(opts.ReviewerTeam) != "" {
+		members, err := report.TeamMembers(api, opts.ReviewerTeam)
+		if err != nil {
+			return err
+		}
+		reviewers, err = teamReviewers(reviewers, members, opts.ReviewerTeam)
+		if err != nil {
+			return err
+		}
+	}
+	excludeReviewers, err := expandMeLogins(api, opts.ExcludeReviewers)
 	if err != nil {
 		return err
 	}
 
-	service := report.NewService(apiClientFactory(identity.Host))
+	service := report.NewService(api)
 	output, err := service.Fetch(identity, report.Options{
-		Reviewer:             strings.TrimSpace(opts.Reviewer),
-		States:               states,
-		StatesProvided:       statesProvided,
-		RequireUnresolved:    opts.Unresolved,
-		RequireNotOutdated:   opts.NotOutdated,
-		TailReplies:          opts.TailReplies,
-		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		Reviewers:             reviewers,
+		ExcludeReviewers:      excludeReviewers,
+		States:                states,
+		StatesProvided:        statesProvided,
+		ExcludeStates:         excludeStates,
+		RequireUnresolved:     opts.Unresolved,
+		RequireNotOutdated:    opts.NotOutdated,
+		RequireOutdated:       opts.OutdatedOnly,
+		TailReplies:           opts.TailReplies,
+		HeadReplies:           opts.HeadReplies,
+		IncludeCommentNodeID:  opts.IncludeCommentNodeID,
+		IncludeDiffHunk:       opts.IncludeDiffHunk,
+		IncludeAuthorID:       opts.IncludeAuthorID,
+		ContextLines:          opts.ContextLines,
+		WithMeta:              opts.WithMeta,
+		Order:                 order,
+		MaxThreads:            opts.MaxThreads,
+		PerPage:               opts.PerPage,
+		MaxPages:              opts.MaxPages,
+		MinSeverity:           minSeverity,
+		DropUnlabeled:         opts.DropUnlabeled,
+		Paths:                 opts.Paths,
+		LineRange:             lineRange,
+		ResolvedBy:            strings.TrimSpace(opts.ResolvedBy),
+		NewSince:              newSince,
+		RequireViewerAuthored: opts.Mine,
+		RequireUnreplied:      opts.OnlyUnreplied,
+		DedupReplies:          opts.DedupReplies,
+		IncludeViewerPending:  opts.IncludeMyPending,
+		AllowGhostAuthors:     opts.AllowGhostAuthors,
+		CollapseResolved:      opts.CollapseResolved,
+		SinceCommit:           sinceCommit,
+		ReportCost:            opts.ReportCost,
 	})
 	if err != nil {
 		return err
 	}
 
-	return encodeJSON(cmd, output)
+	if opts.NoSchemaVersion {
+		output.SchemaVersion = ""
+	}
+	output = report.TruncateBodies(output, opts.MaxBodyLength)
+	switch {
+	case format == "text":
+		err = report.RenderText(cmd.OutOrStdout(), output, palette)
+	case groupBy == "reviewer":
+		err = encodeJSON(cmd, report.GroupByReviewer(output))
+	case opts.Concise:
+		err = encodeJSON(cmd, report.Concise(output))
+	case opts.FileSummary:
+		err = encodeJSON(cmd, fileSummaryResult{Files: report.FileSummary(output)})
+	default:
+		err = encodeJSON(cmd, output)
+	}
+	if err != nil {
+		return err
+	}
+	if output.Truncated {
+		fmt.Fprintln(cmd.ErrOrStderr(), truncationWarning(opts))
+	}
+	if output.Cost != nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), costNote(output.Cost))
+	}
+	if opts.Web {
+		if err := openBrowser(identity.URL()); err != nil {
+			return err
+		}
+	}
+	return reportCheckFailure(output, opts)
+}
+
+// reportCheckFailure applies --fail-on-changes-requested and --fail-on-unresolved
+// to the filtered report.
+func reportCheckFailure(output report.Report, opts *reviewViewOptions) error {
+	if opts.FailOnChangesRequested {
+		for _, review := range output.Reviews {
+			if review.State == report.StateChangesRequested {
+				return &exitCodeError{code: exitCodeFailedCheck, message: "report contains a review requesting changes (--fail-on-changes-requested)"}
+			}
+		}
+	}
+	if opts.FailOnUnresolved {
+		for _, review := range output.Reviews {
+			for _, comment := range review.Comments {
+				if !comment.IsResolved {
+					return &exitCodeError{code: exitCodeFailedCheck, message: "report contains unresolved threads (--fail-on-unresolved)"}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// truncationWarning names the limit that cut the report short. When both caps
+// are set the service does not say which one fired, so both are mentioned;
+// without either, reviews or thread comments overflowed their single page.
+// It is printed in every output mode, including --jsonl, which drops the
+// report's own truncated field.
+func truncationWarning(opts *reviewViewOptions) string {
+	switch {
+	case opts.MaxThreads <= 0 && opts.MaxPages <= 0:
+		return "warning: report truncated: more than 100 reviews or comments in one thread"
+	case opts.MaxThreads > 0 && opts.MaxPages > 0:
+		return fmt.Sprintf("warning: report truncated (--max-threads %d, --max-pages %d)", opts.MaxThreads, opts.MaxPages)
+	case opts.MaxPages > 0:
+		return fmt.Sprintf("warning: report truncated after %d pages of review threads (--max-pages)", opts.MaxPages)
+	default:
+		return fmt.Sprintf("warning: report truncated after %d review threads (--max-threads)", opts.MaxThreads)
+	}
+}
+
+// costNote describes the GraphQL cost reported for --report-cost.
+func costNote(cost *report.QueryCost) string {
+	queries := "queries"
+	if cost.Queries == 1 {
+		queries = "query"
+	}
+	return fmt.Sprintf("graphql cost: %d points over %d %s, %d remaining", cost.Points, cost.Queries, queries, cost.Remaining)
 }
 
 func parseStateFilters(raw []string) ([]report.State, bool, error) {
@@ -98,6 +437,7 @@ func parseStateFilters(raw []string) ([]report.State, bool, error) {
 		"CHANGES_REQUESTED": report.StateChangesRequested,
 		"COMMENTED":         report.StateCommented,
 		"DISMISSED":         report.StateDismissed,
+		"PENDING":           report.StatePending,
 	}
 	allowed := make([]string, 0, len(valid))
 	for key := range valid {
@@ -132,3 +472,93 @@ func parseStateFilters(raw []string) ([]report.State, bool, error) {
 
 	return states, true, nil
 }
+
+// commitSHARE matches a full or abbreviated commit SHA for --since-commit.
+var commitSHARE = regexp.MustCompile(`^[0-9A-Fa-f]{7,40}$`)
+
+// meAlias stands for the authenticated user wherever a login is expected.
+const meAlias = "@me"
+
+// expandMeLogins replaces @me entries (case-insensitive) with the
+// authenticated user's login, looking it up only when the alias is used.
+func expandMeLogins(api ghcli.API, logins []string) ([]string, error) {
+	expanded := make([]string, 0, len(logins))
+	var me string
+	for _, login := range logins {
+		if !strings.EqualFold(strings.TrimSpace(login), meAlias) {
+			expanded = append(expanded, login)
+			continue
+		}
+		if me == "" {
+			current, err := reviewsvc.NewService(api).CurrentLogin()
+			if err != nil {
+				return nil, fmt.Errorf("resolve %s: %w", meAlias, err)
+			}
+			me = current
+		}
+		expanded = append(expanded, me)
+	}
+	return expanded, nil
+}
+
+func parseCommentOrder(raw string) (report.CommentOrder, error) {
+	switch report.CommentOrder(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", report.OrderChronological:
+		return report.OrderChronological, nil
+	case report.OrderPath:
+		return report.OrderPath, nil
+	default:
+		return "", fmt.Errorf("invalid --order value %q (allowed: chronological, path)", raw)
+	}
+}
+
+func parseMinSeverity(raw string) (report.Severity, error) {
+	if strings.TrimSpace(raw) == "" {
+		return 0, nil
+	}
+	sev, ok := report.ParseSeverityName(raw)
+	if !ok {
+		return 0, fmt.Errorf("invalid --min-severity value %q (allowed: nit, suggestion, warning, blocker)", raw)
+	}
+	return sev, nil
+}
+
+func parseLineRange(raw string) (*report.LineRange, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	startRaw, endRaw, ok := strings.Cut(raw, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid --line-range value %q: expected START:END", raw)
+	}
+	start, startErr := strconv.Atoi(strings.TrimSpace(startRaw))
+	end, endErr := strconv.Atoi(strings.TrimSpace(endRaw))
+	if startErr != nil || endErr != nil || start < 1 || end < start {
+		return nil, fmt.Errorf("invalid --line-range value %q: expected START:END with 1 <= START <= END", raw)
+	}
+	return &report.LineRange{Start: start, End: end}, nil
+}
+
+// teamReviewers narrows the --reviewer logins to members of team, or returns
+// the members when no logins were given. An empty result is an error, since
+// an empty reviewer list would lift the filter instead.
+func teamReviewers(reviewers, members []string, team string) ([]string, error) {
+	if len(reviewers) == 0 {
+		return members, nil
+	}
+	inTeam := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		inTeam[strings.ToLower(member)] = struct{}{}
+	}
+	kept := make([]string, 0, len(reviewers))
+	for _, login := range reviewers {
+		if _, ok := inTeam[strings.ToLower(login)]; ok {
+			kept = append(kept, login)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("none of the --reviewer logins belong to team %s", strings.TrimSpace(team))
+	}
+	return kept, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -44,6 +45,7 @@ func TestReviewViewCommandFiltersOutput(t *testing.T) {
 			Comments []struct {
 				ThreadID       string  `json:"thread_id"`
 				CommentNodeID  *string `json:"comment_node_id"`
+				DiffHunk       *string `json:"diff_hunk"`
 				ThreadComments []struct {
 					Body          string  `json:"body"`
 					CommentNodeID *string `json:"comment_node_id"`
@@ -74,6 +76,9 @@ func TestReviewViewCommandFiltersOutput(t *testing.T) {
 	if comment.CommentNodeID != nil {
 		t.Fatalf("expected comment_node_id omitted by default, got %v", *comment.CommentNodeID)
 	}
+	if comment.DiffHunk != nil {
+		t.Fatalf("expected diff_hunk omitted by default, got %v", *comment.DiffHunk)
+	}
 	if len(comment.ThreadComments) != 1 {
 		t.Fatalf("expected 1 reply after tail filter, got %d", len(comment.ThreadComments))
 	}
@@ -105,6 +110,75 @@ func TestReviewViewCommandInvalidState(t *testing.T) {
 	}
 }
 
+func TestReviewViewCommandAcceptsPendingState(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--states", "pending,approved", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	rawStates, ok := fake.variables["states"].([]string)
+	if !ok || len(rawStates) != 2 || rawStates[0] != "PENDING" || rawStates[1] != "APPROVED" {
+		t.Fatalf("expected PENDING and APPROVED states propagated, got %#v", fake.variables["states"])
+	}
+}
+
+func TestReviewViewCommandInvalidOrder(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--order", "random", "51"})
+
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("expected error for invalid order")
+	}
+	if !strings.Contains(err.Error(), "invalid --order") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReviewViewCommandWarnsWhenTruncated(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--max-threads", "1", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var payload struct {
+		Truncated bool `json:"truncated"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if !payload.Truncated {
+		t.Fatal("expected truncated flag in output")
+	}
+	if !strings.Contains(stderr.String(), "truncated after 1 review threads") {
+		t.Fatalf("expected truncation warning on stderr, got %q", stderr.String())
+	}
+}
+
 func TestReviewViewCommandIncludesCommentNodeID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -149,13 +223,228 @@ func TestReviewViewCommandIncludesCommentNodeID(t *testing.T) {
 	}
 }
 
+func TestReviewViewCommandGroupByReviewer(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--group-by", "reviewer", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var payload struct {
+		Reviews   json.RawMessage `json:"reviews"`
+		Reviewers []struct {
+			Login   string `json:"login"`
+			Reviews []struct {
+				ID       string          `json:"id"`
+				Comments json.RawMessage `json:"comments"`
+			} `json:"reviews"`
+			Comments []struct {
+				ThreadID    string `json:"thread_id"`
+				AuthorLogin string `json:"author_login"`
+			} `json:"comments"`
+		} `json:"reviewers"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if payload.Reviews != nil {
+		t.Fatalf("expected no top-level reviews when grouped, got %s", payload.Reviews)
+	}
+	if len(payload.Reviewers) != 2 || payload.Reviewers[0].Login != "alice" || payload.Reviewers[1].Login != "bob" {
+		t.Fatalf("expected reviewers alice and bob, got %+v", payload.Reviewers)
+	}
+	for _, reviewer := range payload.Reviewers {
+		for _, review := range reviewer.Reviews {
+			if review.Comments != nil {
+				t.Fatalf("expected comments to move out of review %s", review.ID)
+			}
+		}
+		for _, comment := range reviewer.Comments {
+			if comment.AuthorLogin != reviewer.Login {
+				t.Fatalf("comment on %s by %s grouped under %s", comment.ThreadID, comment.AuthorLogin, reviewer.Login)
+			}
+		}
+	}
+}
+
+func TestReviewViewCommandRejectsInvalidGroupBy(t *testing.T) {
+	for _, args := range [][]string{
+		{"--group-by", "path"},
+		{"--group-by", "reviewer", "--format", "text"},
+	} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, args...))
+		if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--group-by") {
+			t.Fatalf("expected --group-by error for %v, got %v", args, err)
+		}
+	}
+}
+
+func TestReviewViewCommandIncludesAuthorID(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	type authorIDs struct {
+		Reviews []struct {
+			AuthorID *int64 `json:"author_id"`
+			Comments []struct {
+				AuthorID       *int64 `json:"author_id"`
+				ThreadComments []struct {
+					AuthorID *int64 `json:"author_id"`
+				} `json:"thread_comments"`
+			} `json:"comments"`
+		} `json:"reviews"`
+	}
+	run := func(args ...string) authorIDs {
+		t.Helper()
+		fake := &fakeViewAPI{payload: viewResponse, t: t}
+		apiClientFactory = func(host string) ghcli.API { return fake }
+
+		root := newRootCommand()
+		buf := &bytes.Buffer{}
+		root.SetOut(buf)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "--reviewer", "alice"}, append(args, "51")...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command: %v", err)
+		}
+		var payload authorIDs
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("parse json: %v", err)
+		}
+		if len(payload.Reviews) == 0 || len(payload.Reviews[0].Comments) == 0 || len(payload.Reviews[0].Comments[0].ThreadComments) == 0 {
+			t.Fatalf("expected a review with a replied comment, got %+v", payload)
+		}
+		return payload
+	}
+
+	plain := run()
+	review := plain.Reviews[0]
+	if review.AuthorID != nil || review.Comments[0].AuthorID != nil || review.Comments[0].ThreadComments[0].AuthorID != nil {
+		t.Fatal("expected author_id omitted without --include-author-id")
+	}
+
+	review = run("--include-author-id").Reviews[0]
+	if review.AuthorID == nil || *review.AuthorID != 9001 {
+		t.Fatalf("expected review author_id 9001, got %v", review.AuthorID)
+	}
+	if id := review.Comments[0].AuthorID; id == nil || *id != 9001 {
+		t.Fatalf("expected comment author_id 9001, got %v", id)
+	}
+	if id := review.Comments[0].ThreadComments[0].AuthorID; id == nil || *id != 9002 {
+		t.Fatalf("expected reply author_id 9002, got %v", id)
+	}
+}
+
+func TestReviewViewCommandIncludesDiffHunk(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--include-diff-hunk", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var payload struct {
+		Reviews []struct {
+			Comments []struct {
+				DiffHunk *string `json:"diff_hunk"`
+			} `json:"comments"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if len(payload.Reviews) == 0 || len(payload.Reviews[0].Comments) == 0 {
+		t.Fatal("expected comments in report output")
+	}
+	if hunk := payload.Reviews[0].Comments[0].DiffHunk; hunk == nil || *hunk != "@@ -40,3 +40,4 @@" {
+		t.Fatalf("expected diff_hunk populated, got %v", hunk)
+	}
+}
+
+func TestReviewViewCommandSchemaVersion(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	for _, tc := range []struct {
+		args []string
+		want interface{}
+	}{
+		{args: nil, want: "1"},
+		{args: []string{"--no-schema-version"}, want: nil},
+	} {
+		root := newRootCommand()
+		buf := &bytes.Buffer{}
+		root.SetOut(buf)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, tc.args...))
+
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command: %v", err)
+		}
+
+		var payload map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("parse json: %v", err)
+		}
+		if got := payload["schema_version"]; got != tc.want {
+			t.Fatalf("args %v: expected schema_version %v, got %v", tc.args, tc.want, got)
+		}
+		if !strings.HasPrefix(buf.String(), `{"schema_version"`) && tc.want != nil {
+			t.Fatalf("expected schema_version to lead the output, got %s", buf.String())
+		}
+	}
+}
+
+func TestReviewViewCommandRejectsResolvedByWithUnresolved(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--unresolved", "--resolved-by", "bob", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--resolved-by cannot be combined with --unresolved") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 type fakeViewAPI struct {
 	t         *testing.T
 	payload   []byte
 	variables map[string]interface{}
+	// viewerLogin answers the REST user endpoint used to expand @me.
+	viewerLogin string
+	userCalls   int
 }
 
-func (f *fakeViewAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+func (f *fakeViewAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	if path == "user" && f.viewerLogin != "" {
+		f.userCalls++
+		return assignJSON(result, obj{"login": f.viewerLogin})
+	}
 	f.t.Fatalf("unexpected REST call in view command")
 	return nil
 }
@@ -164,3 +453,621 @@ func (f *fakeViewAPI) GraphQL(query string, variables map[string]interface{}, re
 	f.variables = variables
 	return json.Unmarshal(f.payload, result)
 }
+
+func TestReviewViewCommandReviewerMeAlias(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t, viewerLogin: "bob"}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--reviewer", "@me,@ME", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var payload struct {
+		Reviews []struct {
+			AuthorLogin string `json:"author_login"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if len(payload.Reviews) == 0 {
+		t.Fatal("expected reviews by the authenticated user")
+	}
+	for _, review := range payload.Reviews {
+		if review.AuthorLogin != "bob" {
+			t.Fatalf("expected only bob's reviews, got %s", review.AuthorLogin)
+		}
+	}
+	if fake.userCalls != 1 {
+		t.Fatalf("expected one user lookup, got %d", fake.userCalls)
+	}
+}
+
+func TestReviewViewCommandInvalidMinSeverity(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--min-severity", "critical", "51"})
+
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("expected error for invalid severity")
+	}
+	if !strings.Contains(err.Error(), "invalid --min-severity") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseLineRange(t *testing.T) {
+	r, err := parseLineRange("10:20")
+	if err != nil || r == nil || r.Start != 10 || r.End != 20 {
+		t.Fatalf("unexpected range %+v (err %v)", r, err)
+	}
+	if r, err := parseLineRange(""); err != nil || r != nil {
+		t.Fatalf("expected nil range for empty input, got %+v (err %v)", r, err)
+	}
+	for _, raw := range []string{"10", "20:10", "0:5", "a:b"} {
+		if _, err := parseLineRange(raw); err == nil || !strings.Contains(err.Error(), "invalid --line-range") {
+			t.Fatalf("expected error for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestReviewViewCommandOpensBrowserAfterOutput(t *testing.T) {
+	originalFactory := apiClientFactory
+	originalOpen := openBrowser
+	defer func() {
+		apiClientFactory = originalFactory
+		openBrowser = originalOpen
+	}()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+	var opened []string
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--web", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected report output alongside --web")
+	}
+	if len(opened) != 1 || opened[0] != "https://github.com/agyn/repo/pull/51" {
+		t.Fatalf("unexpected opened urls: %v", opened)
+	}
+}
+
+func TestReviewViewCommandWebOnlySkipsReport(t *testing.T) {
+	originalFactory := apiClientFactory
+	originalOpen := openBrowser
+	defer func() {
+		apiClientFactory = originalFactory
+		openBrowser = originalOpen
+	}()
+
+	apiClientFactory = func(host string) ghcli.API {
+		t.Fatal("unexpected API client for --web-only")
+		return nil
+	}
+	var opened []string
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "report", "--web-only", "https://ghe.example.com/agyn/repo/pull/51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no report output, got %q", buf.String())
+	}
+	if len(opened) != 1 || opened[0] != "https://ghe.example.com/agyn/repo/pull/51" {
+		t.Fatalf("unexpected opened urls: %v", opened)
+	}
+}
+
+func TestReviewViewCommandRejectsHeadAndTailReplies(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--tail", "1", "--head-replies", "1", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--head-replies cannot be combined with --tail") {
+		t.Fatalf("expected mutual exclusion error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandDismissedOnly(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--dismissed-only", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	states, ok := fake.variables["states"].([]string)
+	if !ok || len(states) != 1 || states[0] != "DISMISSED" {
+		t.Fatalf("expected states [DISMISSED], got %#v", fake.variables["states"])
+	}
+
+	root = newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--dismissed-only", "--states", "APPROVED", "51"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--dismissed-only cannot be combined with --states") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandInvalidPerPage(t *testing.T) {
+	for _, value := range []string{"0", "101"} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--per-page", value, "51"})
+
+		err := root.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid --per-page value "+value+": must be between 1 and 100") {
+			t.Fatalf("expected --per-page validation error for %s, got %v", value, err)
+		}
+	}
+}
+
+func TestReviewViewCommandPassesPerPage(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--per-page", "20", "--max-pages", "3", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if got := fake.variables["firstThreads"]; got != 20 {
+		t.Fatalf("expected firstThreads=20, got %v", got)
+	}
+}
+
+func TestReviewViewCommandRejectsStateInBothLists(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"--states", "APPROVED,DISMISSED", "--exclude-states", "dismissed"}, want: "review state DISMISSED cannot appear in both --states and --exclude-states"},
+		{args: []string{"--exclude-states", "MERGED"}, want: `invalid review state "MERGED"`},
+	} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, tc.args...))
+
+		err := root.Execute()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q for %v, got %v", tc.want, tc.args, err)
+		}
+	}
+}
+
+func TestReviewViewCommandRejectsInvalidNewSince(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--new-since", "yesterday", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), `invalid --new-since value "yesterday"`) {
+		t.Fatalf("expected --new-since validation error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandRejectsInvalidSinceCommit(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--since-commit", "main", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), `invalid --since-commit "main"`) {
+		t.Fatalf("expected --since-commit validation error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandRejectsOutdatedOnlyWithNotOutdated(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--outdated-only", "--not_outdated", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--outdated-only cannot be combined with --not_outdated") {
+		t.Fatalf("expected mutual exclusion error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandFailOnChangesRequested(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	for _, tc := range []struct {
+		name     string
+		payload  []byte
+		wantCode int
+	}{
+		{name: "no changes requested", payload: viewResponse},
+		{name: "changes requested", payload: bytes.Replace(viewResponse, []byte(`"state": "APPROVED"`), []byte(`"state": "CHANGES_REQUESTED"`), 1), wantCode: exitCodeFailedCheck},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeViewAPI{payload: tc.payload, t: t}
+			apiClientFactory = func(host string) ghcli.API { return fake }
+
+			root := newRootCommand()
+			buf := &bytes.Buffer{}
+			root.SetOut(buf)
+			root.SetErr(io.Discard)
+			root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--fail-on-changes-requested", "51"})
+
+			err := root.Execute()
+			if tc.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("execute command: %v", err)
+				}
+			} else if err == nil || exitCode(err) != tc.wantCode {
+				t.Fatalf("expected exit code %d, got %v", tc.wantCode, err)
+			}
+			var payload map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+				t.Fatalf("expected the report on stdout before exiting: %v", err)
+			}
+		})
+	}
+}
+
+func TestReviewViewCommandFailOnUnresolved(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "report", "--repo", "agyn/repo", "--fail-on-unresolved", "51"})
+
+	err := root.Execute()
+	if err == nil || exitCode(err) != exitCodeFailedCheck {
+		t.Fatalf("expected exit code %d, got %v", exitCodeFailedCheck, err)
+	}
+	if !strings.Contains(buf.String(), `"is_resolved":false`) {
+		t.Fatalf("expected the report on stdout before exiting, got %s", buf.String())
+	}
+	if exitCode(errors.New("boom")) != 1 {
+		t.Fatal("expected ordinary errors to keep exit code 1")
+	}
+}
+
+func TestReviewViewCommandMineKeepsViewerThreads(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	payload := bytes.Replace(viewResponse, []byte(`"body": "Reply alpha",`), []byte(`"body": "Reply alpha", "viewerDidAuthor": true,`), 1)
+	fake := &fakeViewAPI{payload: payload, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "report", "--repo", "agyn/repo", "--mine", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var output struct {
+		Reviews []struct {
+			Comments []struct {
+				ThreadID string `json:"thread_id"`
+			} `json:"comments"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	var threadIDs []string
+	for _, review := range output.Reviews {
+		for _, comment := range review.Comments {
+			threadIDs = append(threadIDs, comment.ThreadID)
+		}
+	}
+	if strings.Join(threadIDs, ",") != "T1" {
+		t.Fatalf("expected only the thread the viewer replied in, got %v", threadIDs)
+	}
+}
+
+func TestReviewViewCommandMaxBodyLength(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--max-body-length", "6", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"body":"Parent…[truncated]","truncated":true`) {
+		t.Fatalf("expected truncated parent body, got %s", buf.String())
+	}
+
+	root = newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--max-body-length", "-1", "51"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --max-body-length value -1") {
+		t.Fatalf("expected --max-body-length validation error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandIncludeMyPending(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--states", "approved", "--include-my-pending", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	rawStates, ok := fake.variables["states"].([]string)
+	if !ok || strings.Join(rawStates, ",") != "APPROVED,PENDING" {
+		t.Fatalf("expected PENDING requested alongside APPROVED, got %#v", fake.variables["states"])
+	}
+
+	root = newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--exclude-states", "pending", "--include-my-pending", "51"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--include-my-pending cannot be combined with --exclude-states PENDING") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandAllowGhostAuthors(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(viewResponse, &response); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := response["repository"].(map[string]interface{})["pullRequest"].(map[string]interface{})
+	pr["reviews"].(map[string]interface{})["nodes"].([]interface{})[0].(map[string]interface{})["author"] = nil
+	ghosted, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+
+	fake := &fakeViewAPI{payload: ghosted, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "51"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "review missing author login") {
+		t.Fatalf("expected strict author error without the flag, got %v", err)
+	}
+
+	root = newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--allow-ghost-authors", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	var payload struct {
+		Reviews []struct {
+			ID          string `json:"id"`
+			AuthorLogin string `json:"author_login"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(payload.Reviews) == 0 || payload.Reviews[0].ID != "R1" || payload.Reviews[0].AuthorLogin != "ghost" {
+		t.Fatalf("expected R1 attributed to ghost, got %+v", payload.Reviews)
+	}
+}
+
+func TestReviewViewCommandReviewerTeam(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	memberCalls := 0
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		if path != "orgs/agyn/teams/core/members" {
+			t.Fatalf("unexpected REST path %s", path)
+		}
+		memberCalls++
+		return assignJSON(result, []obj{{"login": "alice"}, {"login": "carol"}})
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return json.Unmarshal(viewResponse, result)
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--reviewer-team", "agyn/core", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if memberCalls != 1 {
+		t.Fatalf("expected team members fetched once, got %d", memberCalls)
+	}
+
+	var payload struct {
+		Reviews []struct {
+			ID          string `json:"id"`
+			AuthorLogin string `json:"author_login"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(payload.Reviews) != 1 || payload.Reviews[0].AuthorLogin != "alice" {
+		t.Fatalf("expected only alice's review from the team, got %+v", payload.Reviews)
+	}
+
+	root = newRootCommand()
+	stdout.Reset()
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--reviewer-team", "agyn/core", "--reviewer", "bob,ALICE", "51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command with --reviewer: %v", err)
+	}
+	payload.Reviews = nil
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(payload.Reviews) != 1 || payload.Reviews[0].AuthorLogin != "alice" {
+		t.Fatalf("expected --reviewer narrowed to team members, got %+v", payload.Reviews)
+	}
+
+	root = newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--reviewer-team", "agyn/core", "--reviewer", "bob", "51"})
+	err := root.Execute()
+	if err == nil || err.Error() != "none of the --reviewer logins belong to team agyn/core" {
+		t.Fatalf("expected error for reviewers outside the team, got %v", err)
+	}
+}
+
+func TestReviewViewCommandReportCost(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var withCost map[string]interface{}
+	if err := json.Unmarshal(viewResponse, &withCost); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	withCost["rateLimit"] = obj{"cost": 3, "remaining": 4997}
+	payload, err := json.Marshal(withCost)
+	if err != nil {
+		t.Fatalf("encode fixture: %v", err)
+	}
+
+	for _, tc := range []struct {
+		args       []string
+		wantStderr string
+	}{
+		{args: nil, wantStderr: ""},
+		{args: []string{"--report-cost"}, wantStderr: "graphql cost: 3 points over 1 query, 4997 remaining\n"},
+	} {
+		fake := &fakeViewAPI{payload: viewResponse, t: t}
+		if len(tc.args) > 0 {
+			fake.payload = payload
+		}
+		apiClientFactory = func(host string) ghcli.API { return fake }
+
+		root := newRootCommand()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(stderr)
+		root.SetArgs(append([]string{"review", "view", "--repo", "agyn/repo", "51"}, tc.args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute command %v: %v", tc.args, err)
+		}
+
+		if got := fake.variables["withCost"] == true; got != (len(tc.args) > 0) {
+			t.Fatalf("args %v: expected withCost %v, got variables %v", tc.args, len(tc.args) > 0, fake.variables)
+		}
+		if stderr.String() != tc.wantStderr {
+			t.Fatalf("args %v: expected stderr %q, got %q", tc.args, tc.wantStderr, stderr.String())
+		}
+		if strings.Contains(stdout.String(), "cost") {
+			t.Fatalf("expected cost kept out of the report, got %s", stdout.String())
+		}
+	}
+}
+
+func TestReviewViewCommandFileSummary(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	apiClientFactory = func(host string) ghcli.API { return &fakeViewAPI{payload: viewResponse, t: t} }
+
+	stdout, err := runDraftCommand(t, "review", "view", "--file-summary", "--repo", "agyn/repo", "51")
+	if err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	var payload map[string][]map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("decode output %s: %v", stdout, err)
+	}
+	if len(payload) != 1 || len(payload["files"]) == 0 {
+		t.Fatalf("expected only a non-empty files array, got %s", stdout)
+	}
+	for _, file := range payload["files"] {
+		for _, key := range []string{"path", "comment_count", "unresolved_count", "reviewers"} {
+			if _, ok := file[key]; !ok {
+				t.Fatalf("expected %s in file entry, got %v", key, file)
+			}
+		}
+	}
+
+	if _, err := runDraftCommand(t, "review", "view", "--file-summary", "--concise", "--repo", "agyn/repo", "51"); err == nil || err.Error() != "--file-summary cannot be combined with --concise" {
+		t.Fatalf("expected --concise conflict, got %v", err)
+	}
+}
@@ -1,37 +1,183 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
 )
 
+type rootOptions struct {
+	Timeout        time.Duration
+	RequestTimeout time.Duration
+	NoAutodetect   bool
+	ErrorFormat    string
+
+	cancel context.CancelFunc
+}
+
 // Execute sets up the root command tree and executes it.
 func Execute() error {
-	root := newRootCommand()
+	opts := &rootOptions{}
+	return executeRoot(opts, opts.command())
+}
+
+// executeRoot runs root, then releases the --timeout context. The release is
+// deferred rather than left to PersistentPostRun, which cobra skips when the
+// command fails.
+func executeRoot(opts *rootOptions, root *cobra.Command) error {
+	defer opts.release()
 	return root.Execute()
 }
 
 func newRootCommand() *cobra.Command {
+	return (&rootOptions{}).command()
+}
+
+// command builds the root command tree around o.
+func (o *rootOptions) command() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:           "gh-pr-review",
 		Short:         "PR review helper commands for gh",
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.apply(cmd)
+		},
 	}
 
+	cmd.PersistentFlags().DurationVar(&o.Timeout, "timeout", 0, "Abort the command after the given duration (e.g. 30s, 2m; 0 disables)")
+	cmd.PersistentFlags().DurationVar(&o.RequestTimeout, "request-timeout", 0, "Abort any single GitHub API request after the given duration (0 disables)")
+	cmd.PersistentFlags().StringVar(&o.ErrorFormat, "error-format", "text", "Error output format on stderr (text or json)")
+	cmd.PersistentFlags().Bool("debug", false, "Log each GitHub API call (method, path or operation, parameter names) with timing to stderr")
+	cmd.PersistentFlags().String("capture-dir", "", "Write each raw GitHub API response to timestamped files in this directory (unredacted)")
+	cmd.PersistentFlags().Bool("pretty", false, "Indent JSON output for reading (default is compact)")
+	cmd.PersistentFlags().String("output-file", "", "Write JSON output to this path (atomically, via a temporary file and rename) instead of stdout")
+	cmd.PersistentFlags().String("host", "", "GitHub hostname for numeric selectors (overrides GH_HOST; pull request URLs keep their own host)")
+	cmd.PersistentFlags().BoolVar(&o.NoAutodetect, "no-autodetect", false, "Never infer the pull request from the current branch (also GH_PR_REVIEW_NO_AUTODETECT)")
+
 	cmd.AddCommand(newCommentsCommand())
+	cmd.AddCommand(newRateLimitCommand())
 	cmd.AddCommand(newReviewCommand())
+	cmd.AddCommand(newSchemaCommand())
 	cmd.AddCommand(newThreadsCommand())
 
 	return cmd
 }
 
+// apply normalizes and validates the root flags and installs the command-wide
+// deadline on the executing command's context.
+func (o *rootOptions) apply(cmd *cobra.Command) error {
+	o.ErrorFormat = strings.ToLower(strings.TrimSpace(o.ErrorFormat))
+	switch o.ErrorFormat {
+	case "text", "json":
+	default:
+		return fmt.Errorf("invalid --error-format value %q (allowed: text, json)", o.ErrorFormat)
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("invalid --timeout value %s: must be non-negative", o.Timeout)
+	}
+	if o.RequestTimeout < 0 {
+		return fmt.Errorf("invalid --request-timeout value %s: must be non-negative", o.RequestTimeout)
+	}
+	if o.Timeout == 0 {
+		return nil
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, o.cancel = context.WithTimeout(ctx, o.Timeout)
+	cmd.SetContext(ctx)
+	return nil
+}
+
+func (o *rootOptions) release() {
+	if o.cancel != nil {
+		o.cancel()
+		o.cancel = nil
+	}
+}
+
+// persistentBool reads a boolean flag (root or the command's own) from the executing command, defaulting to false.
+func persistentBool(cmd *cobra.Command, name string) bool {
+	value, err := cmd.Flags().GetBool(name)
+	return err == nil && value
+}
+
 // ExecuteOrExit runs the command tree and exits with a non-zero status on error.
 func ExecuteOrExit() {
-	if err := Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	opts := &rootOptions{}
+	root := opts.command()
+	if err := executeRoot(opts, root); err != nil {
+		reportError(root, err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCodeFailedCheck is the status for --fail-on-* conditions, kept distinct
+// from the generic failure status so scripts can tell them apart.
+const exitCodeFailedCheck = 3
+
+// exitCodeError carries a process exit status other than 1. The command has
+// already written its output; the message only explains the status.
+type exitCodeError struct {
+	code    int
+	message string
+}
+
+func (e *exitCodeError) Error() string {
+	return e.message
+}
+
+// exitCode returns the process status for err.
+func exitCode(err error) int {
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	return 1
+}
+
+// reportError writes err to the root command's stderr in the requested
+// --error-format, as normalized by apply. Errors raised before apply runs,
+// such as unknown flags, fall back to text unless the value is already exact.
+func reportError(root *cobra.Command, err error) {
+	format, _ := root.PersistentFlags().GetString("error-format")
+	writeError(root.ErrOrStderr(), format, err)
+}
+
+type errorPayload struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+func writeError(w io.Writer, format string, err error) {
+	if format != "json" {
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	detail := errorDetail{Message: err.Error()}
+	var apiErr *ghcli.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode > 0 {
+		detail.StatusCode = apiErr.StatusCode
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if encErr := enc.Encode(errorPayload{Error: detail}); encErr != nil {
+		fmt.Fprintln(w, err)
 	}
 }
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextFakeAPI struct {
+	commandFakeAPI
+	contexts []context.Context
+}
+
+func (f *contextFakeAPI) RESTContext(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
+	f.contexts = append(f.contexts, ctx)
+	return f.REST(method, path, params, body, result)
+}
+
+func (f *contextFakeAPI) GraphQLContext(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	f.contexts = append(f.contexts, ctx)
+	return f.GraphQL(query, variables, result)
+}
+
+func TestRootTimeoutPropagatesDeadline(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &contextFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+			"reviews":       obj{"nodes": []obj{}},
+			"reviewThreads": obj{"nodes": []obj{}},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--timeout", "45s", "review", "view", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	require.Len(t, fake.contexts, 1)
+	deadline, ok := fake.contexts[0].Deadline()
+	require.True(t, ok, "expected deadline on request context")
+	assert.WithinDuration(t, time.Now().Add(45*time.Second), deadline, 5*time.Second)
+}
+
+func TestExecuteRootReleasesTimeoutOnError(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &contextFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return errors.New("boom")
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	opts := &rootOptions{}
+	root := opts.command()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--timeout", "45s", "review", "view", "--repo", "octo/demo", "7"})
+
+	require.EqualError(t, executeRoot(opts, root), "boom")
+	require.Len(t, fake.contexts, 1)
+	assert.ErrorIs(t, fake.contexts[0].Err(), context.Canceled)
+}
+
+func TestRootTimeoutRejectsNegative(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--timeout", "-1s", "review", "view", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --timeout")
+}
+
+func TestRootRequestTimeoutRejectsNegative(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--request-timeout", "-1s", "review", "view", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --request-timeout")
+}
+
+func TestErrorFormatJSONIncludesStatusCode(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return &ghcli.APIError{StatusCode: 404, Message: "Not Found"}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(io.Discard)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"--error-format", "json", "review", "view", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	reportError(root, err)
+
+	var payload struct {
+		Error struct {
+			Message    string `json:"message"`
+			StatusCode int    `json:"status_code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), &payload))
+	assert.Equal(t, 404, payload.Error.StatusCode)
+	assert.Contains(t, payload.Error.Message, "Not Found")
+}
+
+func TestErrorFormatJSONOmitsStatusForPlainErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writeError(buf, "json", fmt.Errorf("wrapped: %w", errors.New("boom")))
+	assert.JSONEq(t, `{"error":{"message":"wrapped: boom"}}`, buf.String())
+}
+
+func TestErrorFormatTextIsDefault(t *testing.T) {
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(io.Discard)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"review", "view", "--repo", "octo/demo", "--states", "bogus", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	reportError(root, err)
+	assert.Equal(t, err.Error()+"\n", stderr.String())
+}
+
+func TestErrorFormatIsCaseInsensitive(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return errors.New("boom")
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(io.Discard)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"--error-format", " JSON ", "review", "view", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.EqualError(t, err, "boom")
+	reportError(root, err)
+	assert.JSONEq(t, `{"error":{"message":"boom"}}`, stderr.String())
+}
+
+func TestErrorFormatRejectsUnknownValue(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--error-format", "xml", "review", "view", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --error-format")
+}
+
+func TestDebugFlagLogsAPICalls(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(io.Discard)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"--debug", "review", "view", "--repo", "agyn/repo", "51"})
+
+	require.NoError(t, root.Execute())
+	assert.Regexp(t, `^debug: GraphQL Report \[.*firstThreads.*\] \d+m?s\n$`, stderr.String())
+	assert.NotContains(t, stderr.String(), "agyn")
+}
+
+func TestCaptureDirWritesRawResponses(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	dir := filepath.Join(t.TempDir(), "captures")
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--capture-dir", dir, "review", "view", "--repo", "agyn/repo", "51"})
+
+	require.NoError(t, root.Execute())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-001-graphql-Report.stdout.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, string(viewResponse), string(data))
+	assert.Contains(t, stdout.String(), `"reviews"`)
+}
+
+func TestAPIClientFactoryTokenModeWithoutToken(t *testing.T) {
+	t.Setenv("GH_PR_REVIEW_USE_TOKEN", "1")
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		t.Setenv(name, "")
+	}
+
+	api := apiClientFactory("github.com")
+	err := api.REST("GET", "user", nil, nil, nil)
+	require.EqualError(t, err, "no GitHub token found (set GH_TOKEN or GITHUB_TOKEN)")
+	err = api.GraphQL("query { viewer { login } }", nil, nil)
+	require.EqualError(t, err, "no GitHub token found (set GH_TOKEN or GITHUB_TOKEN)")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/comments"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/schema"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+type schemaTarget struct {
+	title string
+	value interface{}
+}
+
+// schemaTargets maps schema names to the type each command emits; titles match docs/SCHEMAS.md.
+var schemaTargets = map[string]schemaTarget{
+	"report":          {title: "ReviewReport", value: report.Report{}},
+	"reviewer-report": {title: "ReviewerReport", value: report.ReviewerReport{}},
+	"concise-report":  {title: "ConciseReport", value: report.ConciseReport{}},
+	"file-summary":    {title: "FileSummary", value: fileSummaryResult{}},
+	"stats":           {title: "ReviewStats", value: report.Stats{}},
+	"thread":          {title: "ThreadSummary", value: threads.Thread{}},
+	"thread-detail":   {title: "ThreadDetail", value: threads.ThreadDetail{}},
+	"reply":           {title: "ReplyMinimal", value: replyResult{}},
+	"reply-batch":     {title: "ReplyBatchResult", value: replyBatchResult{}},
+	"comment":         {title: "ThreadComment", value: comments.Comment{}},
+}
+
+func newSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:    "schema <name>",
+		Short:  "Print the JSON Schema for a command's output",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.ToLower(strings.TrimSpace(args[0]))
+			target, ok := schemaTargets[name]
+			if !ok {
+				return fmt.Errorf("unknown schema %q (allowed: %s)", args[0], strings.Join(schemaNames(), ", "))
+			}
+			generated := schema.Generate(target.value)
+			generated.Title = target.title
+			return encodeJSON(cmd, generated)
+		},
+	}
+}
+
+func schemaNames() []string {
+	names := make([]string, 0, len(schemaTargets))
+	for name := range schemaTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
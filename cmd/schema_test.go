@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommandPrintsReplySchema(t *testing.T) {
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"schema", "reply"})
+
+	require.NoError(t, root.Execute())
+
+	var payload struct {
+		Title      string                     `json:"title"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "ReplyMinimal", payload.Title)
+	assert.Equal(t, []string{"comment_node_id"}, payload.Required)
+	assert.Contains(t, payload.Properties, "resolution")
+}
+
+func TestSchemaCommandRejectsUnknownName(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"schema", "watch"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, `unknown schema "watch" (allowed: comment, concise-report, file-summary, reply, reply-batch, report, reviewer-report, stats, thread, thread-detail)`, err.Error())
+}
+
+func TestSchemaCommandMatchesSchemasDoc(t *testing.T) {
+	documented, defs := documentedSchemas(t)
+	for _, name := range schemaNames() {
+		t.Run(name, func(t *testing.T) {
+			root := newRootCommand()
+			stdout := &bytes.Buffer{}
+			root.SetOut(stdout)
+			root.SetErr(io.Discard)
+			root.SetArgs([]string{"schema", name})
+			require.NoError(t, root.Execute())
+
+			var generated map[string]interface{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &generated))
+			title, _ := generated["title"].(string)
+			doc, ok := documented[title]
+			require.True(t, ok, "docs/SCHEMAS.md has no %s schema", title)
+			assert.Empty(t, schemaDrift(title, generated, doc, defs))
+		})
+	}
+}
+
+// documentedSchemas reads the JSON blocks of docs/SCHEMAS.md keyed by title,
+// along with the $defs they share.
+func documentedSchemas(t *testing.T) (map[string]map[string]interface{}, map[string]interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "docs", "SCHEMAS.md"))
+	require.NoError(t, err)
+
+	schemas := map[string]map[string]interface{}{}
+	defs := map[string]interface{}{}
+	for _, block := range regexp.MustCompile("(?s)```json\n(.*?)\n```").FindAllSubmatch(data, -1) {
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(block[1], &doc), "invalid JSON block in docs/SCHEMAS.md")
+		title, _ := doc["title"].(string)
+		schemas[title] = doc
+		if blockDefs, ok := doc["$defs"].(map[string]interface{}); ok {
+			for name, def := range blockDefs {
+				defs[name] = def
+			}
+		}
+	}
+	return schemas, defs
+}
+
+// schemaDrift lists where the documented schema's types, properties, or
+// required fields differ from the generated one, recursing into properties
+// and array items. Descriptions, enums, and other annotations are not compared.
+func schemaDrift(path string, generated, documented map[string]interface{}, defs map[string]interface{}) []string {
+	if ref, ok := documented["$ref"].(string); ok {
+		def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolved $ref %s", path, ref)}
+		}
+		documented = def
+	}
+
+	var drift []string
+	if got, want := fmt.Sprint(generated["type"]), fmt.Sprint(documented["type"]); got != want {
+		drift = append(drift, fmt.Sprintf("%s: type is %s, documented as %s", path, got, want))
+	}
+	if got, want := sortedStrings(generated["required"]), sortedStrings(documented["required"]); got != want {
+		drift = append(drift, fmt.Sprintf("%s: required is [%s], documented as [%s]", path, got, want))
+	}
+
+	generatedProps, _ := generated["properties"].(map[string]interface{})
+	documentedProps, _ := documented["properties"].(map[string]interface{})
+	for name, prop := range generatedProps {
+		docProp, ok := documentedProps[name].(map[string]interface{})
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s.%s: not documented", path, name))
+			continue
+		}
+		drift = append(drift, schemaDrift(path+"."+name, prop.(map[string]interface{}), docProp, defs)...)
+	}
+	for name := range documentedProps {
+		if _, ok := generatedProps[name]; !ok {
+			drift = append(drift, fmt.Sprintf("%s.%s: documented but never emitted", path, name))
+		}
+	}
+
+	if items, ok := generated["items"].(map[string]interface{}); ok {
+		docItems, _ := documented["items"].(map[string]interface{})
+		drift = append(drift, schemaDrift(path+"[]", items, docItems, defs)...)
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+func sortedStrings(value interface{}) string {
+	values, _ := value.([]interface{})
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = fmt.Sprint(v)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/autodetect"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// noAutodetectEnv disables pull request detection for scripts that cannot pass --no-autodetect.
+const noAutodetectEnv = "GH_PR_REVIEW_NO_AUTODETECT"
+
+// errNoDetectedPR is returned when the checkout's repository is known but its branch has no pull request.
+var errNoDetectedPR = errors.New("no PR detected for current branch; pass a number")
+
+// resolveIdentity turns the selector, --pr, and --repo inputs into a pull request identity.
+// When neither a selector nor --pr is given, the pull request for the current branch is
+// detected through the gh CLI unless --no-autodetect or GH_PR_REVIEW_NO_AUTODETECT is set.
+func resolveIdentity(cmd *cobra.Command, selector string, pull int, repo string) (resolver.Identity, error) {
+	if strings.TrimSpace(selector) == "" && pull <= 0 && autodetectEnabled(cmd) {
+		return detectIdentity(cmd.Context(), repo)
+	}
+
+	normalized, err := resolver.NormalizeSelector(selector, pull)
+	if err != nil {
+		return resolver.Identity{}, err
+	}
+	return resolver.Resolve(normalized, repo, defaultHost(cmd))
+}
+
+// defaultHost is the host applied to numeric selectors: --host when set,
+// otherwise GH_HOST. A pull request URL selector always keeps its own host.
+func defaultHost(cmd *cobra.Command) string {
+	if host, err := cmd.Flags().GetString("host"); err == nil && strings.TrimSpace(host) != "" {
+		return host
+	}
+	return os.Getenv("GH_HOST")
+}
+
+func detectIdentity(ctx context.Context, repo string) (resolver.Identity, error) {
+	// Reuse the resolver's error for a missing selector when detection is not possible.
+	_, selectorErr := resolver.NormalizeSelector("", 0)
+
+	detected, err := detectPullRequest(ctx)
+	if err != nil {
+		var detectErr *autodetect.DetectionError
+		if errors.As(err, &detectErr) {
+			return resolver.Identity{}, selectorErr
+		}
+		return resolver.Identity{}, err
+	}
+	fullName := detected.Owner + "/" + detected.Repo
+	if repo = strings.TrimSpace(repo); repo != "" && !strings.EqualFold(repo, fullName) {
+		return resolver.Identity{}, selectorErr
+	}
+	if detected.Number <= 0 {
+		return resolver.Identity{}, fmt.Errorf("%w (repository %s)", errNoDetectedPR, fullName)
+	}
+	return resolver.Resolve(strconv.Itoa(detected.Number), fullName, detected.Host)
+}
+
+func autodetectEnabled(cmd *cobra.Command) bool {
+	if persistentBool(cmd, "no-autodetect") {
+		return false
+	}
+	raw := strings.TrimSpace(os.Getenv(noAutodetectEnv))
+	if raw == "" {
+		return true
+	}
+	disabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		// Any non-boolean value still signals intent to disable detection.
+		return false
+	}
+	return !disabled
+}
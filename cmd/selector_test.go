@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/autodetect"
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubDetect(t *testing.T, result autodetect.Result, err error) *int {
+	t.Helper()
+	calls := 0
+	original := detectPullRequest
+	detectPullRequest = func(context.Context) (autodetect.Result, error) {
+		calls++
+		return result, err
+	}
+	t.Cleanup(func() { detectPullRequest = original })
+	return &calls
+}
+
+func TestReviewViewAutodetectsPullRequest(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	calls := stubDetect(t, autodetect.Result{Owner: "octo", Repo: "demo", Host: "ghe.example.com", Number: 42}, nil)
+
+	var gotHost string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		assert.Equal(t, "octo", variables["owner"])
+		assert.Equal(t, "demo", variables["name"])
+		assert.Equal(t, 42, variables["number"])
+		return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+			"reviews":       obj{"nodes": []obj{}},
+			"reviewThreads": obj{"nodes": []obj{}},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API {
+		gotHost = host
+		return fake
+	}
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, "ghe.example.com", gotHost)
+	assert.JSONEq(t, `{"schema_version":"1","reviews":[]}`, stdout.String())
+}
+
+func TestAutodetectHonorsTimeout(t *testing.T) {
+	original := detectPullRequest
+	t.Cleanup(func() { detectPullRequest = original })
+	var deadline time.Time
+	var hasDeadline bool
+	detectPullRequest = func(ctx context.Context) (autodetect.Result, error) {
+		deadline, hasDeadline = ctx.Deadline()
+		return autodetect.Result{}, context.DeadlineExceeded
+	}
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--timeout", "2s", "threads", "list"})
+
+	err := root.Execute()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, hasDeadline, "expected --timeout deadline on the detection context")
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), deadline, time.Second)
+}
+
+func TestAutodetectRepoWithoutPullRequest(t *testing.T) {
+	stubDetect(t, autodetect.Result{Owner: "octo", Repo: "demo", Host: "github.com"}, nil)
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"threads", "list"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errNoDetectedPR)
+	assert.Contains(t, err.Error(), "no PR detected for current branch; pass a number")
+}
+
+func TestAutodetectFailureFallsBackToSelectorError(t *testing.T) {
+	stubDetect(t, autodetect.Result{}, &autodetect.DetectionError{Err: errors.New("not a git repository")})
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_x", "--body", "ack"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must specify a pull request")
+}
+
+func TestAutodetectSurfacesGhFailures(t *testing.T) {
+	stubDetect(t, autodetect.Result{}, errors.New("detect pull request for current branch: HTTP 502"))
+
+	_, err := resolveIdentity(&cobra.Command{}, "", 0, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 502")
+}
+
+func TestAutodetectSkippedWhenSelectorProvided(t *testing.T) {
+	calls := stubDetect(t, autodetect.Result{}, errors.New("should not be called"))
+
+	_, err := resolveIdentity(&cobra.Command{}, "7", 0, "octo/demo")
+	require.NoError(t, err)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestAutodetectRejectsMismatchedRepo(t *testing.T) {
+	stubDetect(t, autodetect.Result{Owner: "octo", Repo: "demo", Host: "github.com", Number: 3}, nil)
+
+	_, err := resolveIdentity(&cobra.Command{}, "", 0, "other/repo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must specify a pull request")
+}
+
+func TestNoAutodetectFlagSkipsDetection(t *testing.T) {
+	calls := stubDetect(t, autodetect.Result{Owner: "octo", Repo: "demo", Host: "github.com", Number: 42}, nil)
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--no-autodetect", "review", "view"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must specify a pull request")
+	assert.Equal(t, 0, *calls)
+}
+
+func TestNoAutodetectEnvSkipsDetection(t *testing.T) {
+	calls := stubDetect(t, autodetect.Result{Owner: "octo", Repo: "demo", Host: "github.com", Number: 42}, nil)
+
+	for _, value := range []string{"1", "true", "yes"} {
+		t.Setenv(noAutodetectEnv, value)
+
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"threads", "list"})
+
+		err := root.Execute()
+		require.Error(t, err, "value %q", value)
+		assert.Contains(t, err.Error(), "must specify a pull request")
+	}
+	assert.Equal(t, 0, *calls)
+
+	t.Setenv(noAutodetectEnv, "0")
+	identity, err := resolveIdentity(&cobra.Command{}, "", 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 42, identity.Number)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestHostPrecedence(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	cases := []struct {
+		name string
+		env  string
+		args []string
+		want string
+	}{
+		{name: "default", args: []string{"--repo", "octo/demo", "7"}, want: "github.com"},
+		{name: "GH_HOST", env: "env.example.com", args: []string{"--repo", "octo/demo", "7"}, want: "env.example.com"},
+		{name: "--host beats GH_HOST", env: "env.example.com", args: []string{"--host", "flag.example.com", "--repo", "octo/demo", "7"}, want: "flag.example.com"},
+		{name: "URL beats --host", env: "env.example.com", args: []string{"--host", "flag.example.com", "https://url.example.com/octo/demo/pull/7"}, want: "url.example.com"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GH_HOST", tc.env)
+			var gotHost string
+			fake := &commandFakeAPI{}
+			fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+					"reviews":       obj{"nodes": []obj{}},
+					"reviewThreads": obj{"nodes": []obj{}},
+				}}})
+			}
+			apiClientFactory = func(host string) ghcli.API {
+				gotHost = host
+				return fake
+			}
+
+			root := newRootCommand()
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			root.SetArgs(append([]string{"review", "view"}, tc.args...))
+
+			require.NoError(t, root.Execute())
+			assert.Equal(t, tc.want, gotHost)
+		})
+	}
+}
@@ -2,11 +2,11 @@ package cmd
 
 import (
 	"errors"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/agynio/gh-pr-review/internal/comments"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 	"github.com/agynio/gh-pr-review/internal/threads"
 )
@@ -18,6 +18,7 @@ func newThreadsCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newThreadsListCommand())
+	cmd.AddCommand(newThreadsShowCommand())
 	cmd.AddCommand(newThreadsResolveCommand())
 	cmd.AddCommand(newThreadsUnresolveCommand())
 
@@ -41,6 +42,17 @@ func newThreadsListCommand() *cobra.Command {
 
 	cmd.Flags().BoolVar(&opts.UnresolvedOnly, "unresolved", false, "Filter to unresolved threads only")
 	cmd.Flags().BoolVar(&opts.MineOnly, "mine", false, "Show only threads involving or resolvable by the viewer")
+	cmd.Flags().BoolVar(&opts.OutdatedOnly, "outdated-only", false, "Show only threads made outdated by later pushes")
+	cmd.Flags().BoolVar(&opts.NotOutdated, "not-outdated", false, "Hide threads made outdated by later pushes")
+	cmd.MarkFlagsMutuallyExclusive("outdated-only", "not-outdated")
+	cmd.Flags().StringArrayVar(&opts.Paths, "path", nil, "Only include threads whose file matches the glob (repeatable; ** matches directories)")
+	cmd.Flags().StringVar(&opts.Sort, "sort", string(threads.SortUpdated), "Order threads by updated, path, or created")
+	cmd.Flags().BoolVar(&opts.Ascending, "asc", false, "Sort in ascending order (default for path)")
+	cmd.Flags().BoolVar(&opts.Descending, "desc", false, "Sort in descending order (default for updated and created)")
+	cmd.MarkFlagsMutuallyExclusive("asc", "desc")
+	cmd.Flags().Bool("jsonl", false, "Print each thread as its own compact JSON line instead of one array")
+	cmd.Flags().BoolVar(&opts.Stats, "stats", false, "Wrap the threads in an object with total, resolved, unresolved, and outdated counts")
+	cmd.MarkFlagsMutuallyExclusive("stats", "jsonl")
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
@@ -53,32 +65,116 @@ type threadsListOptions struct {
 	Selector       string
 	UnresolvedOnly bool
 	MineOnly       bool
+	OutdatedOnly   bool
+	NotOutdated    bool
+	Paths          []string
+	Sort           string
+	Ascending      bool
+	Descending     bool
+	Stats          bool
+}
+
+// threadsListStats is the --stats output: counts over the listed threads,
+// followed by the threads themselves.
+type threadsListStats struct {
+	Total      int              `json:"total"`
+	Resolved   int              `json:"resolved"`
+	Unresolved int              `json:"unresolved"`
+	Outdated   int              `json:"outdated"`
+	Threads    []threads.Thread `json:"threads"`
 }
 
 func runThreadsList(cmd *cobra.Command, opts *threadsListOptions) error {
-	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
 	if err != nil {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
-	if err != nil {
-		return err
+	var direction threads.SortDirection
+	switch {
+	case opts.Ascending:
+		direction = threads.SortAscending
+	case opts.Descending:
+		direction = threads.SortDescending
 	}
 
-	service := threads.NewService(apiClientFactory(identity.Host))
+	service := threads.NewService(newAPIClient(cmd, identity.Host))
 	payload, err := service.List(identity, threads.ListOptions{
-		OnlyUnresolved: opts.UnresolvedOnly,
-		MineOnly:       opts.MineOnly,
+		OnlyUnresolved:  opts.UnresolvedOnly,
+		MineOnly:        opts.MineOnly,
+		OnlyOutdated:    opts.OutdatedOnly,
+		OnlyNotOutdated: opts.NotOutdated,
+		Paths:           opts.Paths,
+		Sort:            threads.SortKey(strings.ToLower(strings.TrimSpace(opts.Sort))),
+		Direction:       direction,
 	})
 	if err != nil {
 		return err
 	}
 
+	if opts.Stats {
+		stats := threadsListStats{Total: len(payload), Threads: payload}
+		for _, thread := range payload {
+			if thread.IsResolved {
+				stats.Resolved++
+			} else {
+				stats.Unresolved++
+			}
+			if thread.IsOutdated {
+				stats.Outdated++
+			}
+		}
+		return encodeJSON(cmd, stats)
+	}
 	return encodeJSON(cmd, payload)
 }
 
+func newThreadsShowCommand() *cobra.Command {
+	opts := &threadsShowOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show [<number> | <url>]",
+		Short: "Show a review thread with its full comment chain",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if strings.TrimSpace(opts.ThreadID) == "" {
+				return errors.New("--thread-id is required")
+			}
+			return runThreadsShow(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+
+	return cmd
+}
+
+type threadsShowOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	ThreadID string
+}
+
+func runThreadsShow(cmd *cobra.Command, opts *threadsShowOptions) error {
+	identity, err := resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := threads.NewService(newAPIClient(cmd, identity.Host))
+	detail, err := service.Show(identity, opts.ThreadID)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, detail)
+}
+
 func newThreadsResolveCommand() *cobra.Command {
 	return newThreadsMutationCommand(true)
 }
@@ -116,6 +212,9 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.Flags().StringVar(&opts.ThreadURL, "thread-url", "", "Comment permalink (…/pull/N#discussion_r<id>); targets that comment's thread")
+	cmd.Flags().BoolVar(&opts.Concise, "concise", false, "Print only the thread_node_id")
+	cmd.MarkFlagsMutuallyExclusive("thread-id", "thread-url")
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
@@ -123,15 +222,17 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 }
 
 type threadsMutationOptions struct {
-	Repo     string
-	Pull     int
-	Selector string
-	ThreadID string
+	Repo      string
+	Pull      int
+	Selector  string
+	ThreadID  string
+	ThreadURL string
+	Concise   bool
 }
 
 func (o *threadsMutationOptions) Validate() error {
-	if strings.TrimSpace(o.ThreadID) == "" {
-		return errors.New("--thread-id is required")
+	if strings.TrimSpace(o.ThreadID) == "" && strings.TrimSpace(o.ThreadURL) == "" {
+		return errors.New("--thread-id is required (or pass --thread-url)")
 	}
 	return nil
 }
@@ -145,19 +246,31 @@ func runThreadsUnresolve(cmd *cobra.Command, opts *threadsMutationOptions) error
 }
 
 func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolve bool) error {
-	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	var (
+		identity  resolver.Identity
+		commentID int64
+		err       error
+	)
+	if strings.TrimSpace(opts.ThreadURL) != "" {
+		identity, commentID, err = resolveThreadURL(cmd, opts.ThreadURL, opts.Selector, opts.Pull, opts.Repo)
+	} else {
+		identity, err = resolveIdentity(cmd, opts.Selector, opts.Pull, opts.Repo)
+	}
 	if err != nil {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
-	if err != nil {
-		return err
+	api := newAPIClient(cmd, identity.Host)
+	threadID := strings.TrimSpace(opts.ThreadID)
+	if commentID != 0 {
+		threadID, err = comments.NewService(api).ThreadForComment(identity, commentID)
+		if err != nil {
+			return err
+		}
 	}
 
-	service := threads.NewService(apiClientFactory(identity.Host))
-	action := threads.ActionOptions{ThreadID: strings.TrimSpace(opts.ThreadID)}
+	service := threads.NewService(api)
+	action := threads.ActionOptions{ThreadID: threadID}
 
 	var result threads.ActionResult
 	if resolve {
@@ -168,5 +281,8 @@ func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolv
 	if err != nil {
 		return err
 	}
+	if opts.Concise {
+		return encodeJSON(cmd, map[string]string{"thread_node_id": result.ThreadNodeID})
+	}
 	return encodeJSON(cmd, result)
 }
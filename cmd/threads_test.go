@@ -101,6 +101,81 @@ func TestThreadsListCommandOutputsJSON(t *testing.T) {
 	assert.Equal(t, float64(27), payload[0]["line"])
 }
 
+func TestThreadsListCommandStats(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, map[string]interface{}{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/5":
+			return assignJSON(result, map[string]interface{}{"node_id": "PR_node"})
+		default:
+			return errors.New("unexpected path")
+		}
+	}
+	thread := func(id string, resolved, outdated bool) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         id,
+			"isResolved": resolved,
+			"isOutdated": outdated,
+			"path":       "internal/service.go",
+			"comments":   map[string]interface{}{"nodes": []map[string]interface{}{}},
+		}
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assignJSON(result, map[string]interface{}{
+			"node": map[string]interface{}{
+				"reviewThreads": map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						thread("T_open", false, false),
+						thread("T_open_outdated", false, true),
+						thread("T_resolved", true, false),
+						thread("T_resolved_outdated", true, true),
+						thread("T_resolved_again", true, false),
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			},
+		})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	stdout, err := runDraftCommand(t, "threads", "list", "--stats", "--repo", "octo/demo", "5")
+	require.NoError(t, err)
+
+	var payload struct {
+		Total      int                      `json:"total"`
+		Resolved   int                      `json:"resolved"`
+		Unresolved int                      `json:"unresolved"`
+		Outdated   int                      `json:"outdated"`
+		Threads    []map[string]interface{} `json:"threads"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &payload))
+	assert.Equal(t, 5, payload.Total)
+	assert.Equal(t, 3, payload.Resolved)
+	assert.Equal(t, 2, payload.Unresolved)
+	assert.Equal(t, 2, payload.Outdated)
+	assert.Len(t, payload.Threads, 5)
+
+	_, err = runDraftCommand(t, "threads", "list", "--stats", "--jsonl", "--repo", "octo/demo", "5")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[jsonl stats] were all set")
+}
+
+func TestThreadsListOutdatedFlagsAreExclusive(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "list", "--outdated-only", "--not-outdated", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[not-outdated outdated-only] were all set")
+}
+
 func TestThreadsResolveCommandByThreadID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -207,6 +282,87 @@ func TestThreadsUnresolveCommandByThreadID(t *testing.T) {
 	assert.Equal(t, false, payload["is_resolved"])
 }
 
+func TestThreadsResolveCommandConcise(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "T_thread", "isResolved": true, "viewerCanResolve": true, "viewerCanUnresolve": true}})
+		case strings.Contains(query, "unresolveReviewThread"):
+			return assignJSON(result, obj{"unresolveReviewThread": obj{"thread": obj{"id": "T_thread", "isResolved": false}}})
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetArgs([]string{"threads", "unresolve", "--thread-id", "T_thread", "--concise", "--repo", "octo/demo", "9"})
+
+	require.NoError(t, root.Execute())
+	assert.Empty(t, stderr.String())
+	assertJSONEqual(t, `{"thread_node_id":"T_thread"}`, stdout.Bytes())
+}
+
+func TestThreadsResolveCommandByThreadURL(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var resolved interface{}
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewThreadCommentIDs"):
+			assert.Equal(t, "o", variables["owner"])
+			assert.Equal(t, "r", variables["name"])
+			assert.Equal(t, 9, variables["number"])
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"reviewThreads": obj{
+				"nodes": []obj{
+					{"id": "PRRT_other", "comments": obj{"nodes": []obj{{"databaseId": 1}}, "pageInfo": obj{"hasNextPage": false}}},
+					{"id": "PRRT_thread", "comments": obj{"nodes": []obj{{"databaseId": 123456}}, "pageInfo": obj{"hasNextPage": false}}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}})
+		case strings.Contains(query, "ThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "isResolved": false, "viewerCanResolve": true, "viewerCanUnresolve": true}})
+		case strings.Contains(query, "resolveReviewThread"):
+			resolved = variables["threadId"]
+			return assignJSON(result, obj{"resolveReviewThread": obj{"thread": obj{"id": "PRRT_thread", "isResolved": true}}})
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-url", "https://github.com/o/r/pull/9#discussion_r123456", "--concise"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "PRRT_thread", resolved)
+	assertJSONEqual(t, `{"thread_node_id":"PRRT_thread"}`, stdout.Bytes())
+}
+
+func TestThreadsResolveRejectsThreadIDWithThreadURL(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-id", "PRRT_thread", "--thread-url", "https://github.com/o/r/pull/9#discussion_r1"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the others can be")
+}
+
 func TestThreadsUnresolveRequiresIdentifier(t *testing.T) {
 	root := newRootCommand()
 	root.SetOut(&bytes.Buffer{})
@@ -228,3 +384,71 @@ func TestThreadsResolveRequiresThreadID(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--thread-id is required")
 }
+
+func TestThreadsShowCommandOutputsDetail(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "ThreadShow")
+		assert.Equal(t, "PRRT_thread", variables["id"])
+		return assignJSON(result, obj{
+			"node": obj{
+				"id":         "PRRT_thread",
+				"path":       "cmd/root.go",
+				"line":       12,
+				"isResolved": true,
+				"isOutdated": false,
+				"resolvedBy": obj{"login": "octocat"},
+				"comments": obj{
+					"nodes": []obj{{
+						"id":         "PRRC_1",
+						"databaseId": 101,
+						"body":       "Rename this",
+						"createdAt":  "2025-12-03T10:00:00Z",
+						"diffHunk":   "@@ -10,2 +10,3 @@",
+						"author":     obj{"login": "reviewer"},
+					}},
+					"pageInfo": obj{"hasNextPage": false},
+				},
+			},
+		})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "show", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "9"})
+
+	require.NoError(t, root.Execute())
+	assertJSONEqual(t, `{
+		"thread_id": "PRRT_thread",
+		"path": "cmd/root.go",
+		"line": 12,
+		"is_resolved": true,
+		"resolved_by": "octocat",
+		"is_outdated": false,
+		"comments": [{
+			"comment_node_id": "PRRC_1",
+			"database_id": 101,
+			"author_login": "reviewer",
+			"body": "Rename this",
+			"created_at": "2025-12-03T10:00:00Z",
+			"diff_hunk": "@@ -10,2 +10,3 @@"
+		}]
+	}`, stdout.Bytes())
+}
+
+func TestThreadsShowRequiresThreadID(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "show", "octo/demo#1"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--thread-id is required")
+}
@@ -0,0 +1,358 @@
+// Package autodetect infers the pull request for the current git checkout
+// through the `gh` CLI, mirroring how `gh pr view` picks a default.
+package autodetect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// DefaultCacheTTL bounds how long a detection persisted to the file cache is reused.
+const DefaultCacheTTL = 15 * time.Second
+
+// DefaultTimeout bounds each `gh` subprocess used for detection. A context
+// passed to DetectContext can cut it shorter.
+const DefaultTimeout = 5 * time.Second
+
+// Options tunes DetectWithOptions.
+type Options struct {
+	// NoCache bypasses both the in-process memo and the file cache.
+	NoCache bool
+	// CacheDir holds the file cache; empty uses the user cache directory.
+	CacheDir string
+	// CacheTTL overrides DefaultCacheTTL for file cache entries.
+	CacheTTL time.Duration
+	// Timeout overrides DefaultTimeout for each `gh` subprocess.
+	Timeout time.Duration
+}
+
+// Result describes the repository and pull request detected for the working directory.
+// Number is zero when the repository was found but the branch has no pull request.
+type Result struct {
+	Owner  string
+	Repo   string
+	Host   string
+	Number int
+}
+
+// DetectionError reports that no GitHub repository could be inferred from the working directory.
+type DetectionError struct {
+	Err error
+}
+
+func (e *DetectionError) Error() string {
+	return fmt.Sprintf("unable to detect repository from current directory: %v", e.Err)
+}
+
+func (e *DetectionError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	memoMu sync.Mutex
+	memo   = map[string]Result{}
+
+	// now and getwd are replaced in tests.
+	now   = time.Now
+	getwd = os.Getwd
+)
+
+// errTimeout marks a `gh` subprocess killed after the detection timeout.
+var errTimeout = errors.New("timed out")
+
+// waitDelay bounds how long runGh waits for output pipes to close after a
+// timed-out subprocess is killed.
+const waitDelay = time.Second
+
+// runGh executes `gh` with the given arguments and returns stdout, killing it
+// when ctx is done or after timeout, whichever comes first; replaced in tests.
+var runGh = func(ctx context.Context, timeout time.Duration, args ...string) ([]byte, error) {
+	executable, err := ghcli.Executable("")
+	if err != nil {
+		return nil, err
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, executable, args...)
+	cmd.WaitDelay = waitDelay
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("gh %s: %w", strings.Join(args, " "), ctxErr)
+		}
+		if runCtx.Err() != nil {
+			return nil, fmt.Errorf("gh %s: %w after %s", strings.Join(args, " "), errTimeout, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("gh %s: %s", strings.Join(args, " "), msg)
+		}
+		return nil, fmt.Errorf("gh %s: %w", strings.Join(args, " "), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Detect resolves the repository of the current checkout and the pull request
+// associated with its branch. A *DetectionError is returned when the repository
+// cannot be determined. Results are cached per working directory and, across
+// processes, per checked-out branch or commit.
+func Detect() (Result, error) {
+	return DetectContext(context.Background(), Options{})
+}
+
+// DetectWithOptions behaves like Detect with caching controlled by opts.
+func DetectWithOptions(opts Options) (Result, error) {
+	return DetectContext(context.Background(), opts)
+}
+
+// DetectContext behaves like DetectWithOptions, additionally bounding every
+// `gh` subprocess by ctx. A cancelled or expired ctx is returned as an error
+// rather than a *DetectionError or a missing pull request.
+func DetectContext(ctx context.Context, opts Options) (Result, error) {
+	wd, wdErr := getwd()
+	useCache := !opts.NoCache && wdErr == nil
+	var fileKey string
+	if useCache {
+		if head, ok := readHead(wd); ok {
+			fileKey = wd + "@" + head
+		}
+	}
+
+	if useCache {
+		memoMu.Lock()
+		cached, ok := memo[wd]
+		memoMu.Unlock()
+		if ok {
+			return cached, nil
+		}
+		if fileKey != "" {
+			if cached, ok := readFileCache(opts, fileKey); ok {
+				remember(wd, cached)
+				return cached, nil
+			}
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	result, err := detectRepo(ctx, timeout)
+	if err != nil {
+		if ctx.Err() != nil {
+			return Result{}, err
+		}
+		return Result{}, &DetectionError{Err: err}
+	}
+	number, err := detectPR(ctx, timeout)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Number = number
+
+	if useCache {
+		remember(wd, result)
+		if fileKey != "" && result.Number > 0 {
+			writeFileCache(opts, fileKey, result)
+		}
+	}
+	return result, nil
+}
+
+func remember(wd string, result Result) {
+	memoMu.Lock()
+	memo[wd] = result
+	memoMu.Unlock()
+}
+
+type cacheEntry struct {
+	Owner      string    `json:"owner"`
+	Repo       string    `json:"repo"`
+	Host       string    `json:"host"`
+	Number     int       `json:"number"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+func cachePath(opts Options) (string, bool) {
+	dir := opts.CacheDir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", false
+		}
+		dir = filepath.Join(base, "gh-pr-review")
+	}
+	return filepath.Join(dir, "autodetect.json"), true
+}
+
+func loadCacheEntries(path string) map[string]cacheEntry {
+	entries := map[string]cacheEntry{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entries
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return map[string]cacheEntry{}
+	}
+	return entries
+}
+
+// readHead returns the contents of the HEAD file of the git checkout
+// containing dir: a branch ref, or a commit SHA when detached. It reads the
+// file directly rather than running git, and handles worktrees whose .git is
+// a file pointing at the real git directory.
+func readHead(dir string) (string, bool) {
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			gitDir := dotGit
+			if !info.IsDir() {
+				data, err := os.ReadFile(dotGit)
+				if err != nil {
+					return "", false
+				}
+				target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+				if !ok {
+					return "", false
+				}
+				gitDir = strings.TrimSpace(target)
+				if !filepath.IsAbs(gitDir) {
+					gitDir = filepath.Join(dir, gitDir)
+				}
+			}
+			data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+			if err != nil {
+				return "", false
+			}
+			head := strings.TrimSpace(string(data))
+			return head, head != ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// readFileCache returns a fresh cached detection for key. Cache problems are treated as misses.
+func readFileCache(opts Options, key string) (Result, bool) {
+	path, ok := cachePath(opts)
+	if !ok {
+		return Result{}, false
+	}
+	entry, ok := loadCacheEntries(path)[key]
+	if !ok || entry.Number <= 0 {
+		return Result{}, false
+	}
+	ttl := opts.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if now().Sub(entry.DetectedAt) > ttl {
+		return Result{}, false
+	}
+	return Result{Owner: entry.Owner, Repo: entry.Repo, Host: entry.Host, Number: entry.Number}, true
+}
+
+// writeFileCache persists result for key on a best-effort basis. The file is
+// replaced through a rename so concurrent runs never read a partial write.
+func writeFileCache(opts Options, key string, result Result) {
+	path, ok := cachePath(opts)
+	if !ok {
+		return
+	}
+	entries := loadCacheEntries(path)
+	entries[key] = cacheEntry{
+		Owner:      result.Owner,
+		Repo:       result.Repo,
+		Host:       result.Host,
+		Number:     result.Number,
+		DetectedAt: now().UTC(),
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "autodetect-*.json")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func detectRepo(ctx context.Context, timeout time.Duration) (Result, error) {
+	out, err := runGh(ctx, timeout, "repo", "view", "--json", "owner,name,url")
+	if err != nil {
+		return Result{}, err
+	}
+
+	var repo struct {
+		Name  string `json:"name"`
+		URL   string `json:"url"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	}
+	if err := json.Unmarshal(out, &repo); err != nil {
+		return Result{}, fmt.Errorf("parse gh repo view output: %w", err)
+	}
+
+	owner := strings.TrimSpace(repo.Owner.Login)
+	name := strings.TrimSpace(repo.Name)
+	if owner == "" || name == "" {
+		return Result{}, errors.New("gh repo view returned incomplete repository data")
+	}
+
+	host := "github.com"
+	if u, err := url.Parse(strings.TrimSpace(repo.URL)); err == nil && u.Host != "" {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	return Result{Owner: owner, Repo: name, Host: host}, nil
+}
+
+// detectPR returns the pull request number for the current branch. It returns
+// (0, nil) when the branch has no pull request or `gh` timed out, and an error
+// when `gh` itself fails.
+func detectPR(ctx context.Context, timeout time.Duration) (int, error) {
+	out, err := runGh(ctx, timeout, "pr", "view", "--json", "number")
+	if err != nil {
+		if ctx.Err() == nil && (isNoPullRequest(err) || errors.Is(err, errTimeout)) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("detect pull request for current branch: %w", err)
+	}
+	var pr struct {
+		Number int `json:"number"`
+	}
+	if err := json.Unmarshal(out, &pr); err != nil {
+		return 0, fmt.Errorf("parse gh pr view output: %w", err)
+	}
+	return pr.Number, nil
+}
+
+func isNoPullRequest(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "no pull requests found")
+}
@@ -0,0 +1,250 @@
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func stubRunGh(t *testing.T, fn func(args ...string) ([]byte, error)) {
+	t.Helper()
+	original := runGh
+	runGh = func(_ context.Context, _ time.Duration, args ...string) ([]byte, error) {
+		return fn(args...)
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	resetMemo()
+	t.Cleanup(func() {
+		runGh = original
+		resetMemo()
+	})
+}
+
+func resetMemo() {
+	memoMu.Lock()
+	memo = map[string]Result{}
+	memoMu.Unlock()
+}
+
+func TestDetectRepoAndPR(t *testing.T) {
+	stubRunGh(t, func(args ...string) ([]byte, error) {
+		switch strings.Join(args[:2], " ") {
+		case "repo view":
+			return []byte(`{"name":"demo","url":"https://GHE.example.com/octo/demo","owner":{"login":"octo"}}`), nil
+		case "pr view":
+			return []byte(`{"number":42}`), nil
+		default:
+			return nil, errors.New("unexpected args")
+		}
+	})
+
+	result, err := Detect()
+	require.NoError(t, err)
+	assert.Equal(t, Result{Owner: "octo", Repo: "demo", Host: "ghe.example.com", Number: 42}, result)
+}
+
+func TestDetectRepoWithoutPR(t *testing.T) {
+	stubRunGh(t, func(args ...string) ([]byte, error) {
+		if args[0] == "repo" {
+			return []byte(`{"name":"demo","url":"https://github.com/octo/demo","owner":{"login":"octo"}}`), nil
+		}
+		return nil, errors.New("no pull requests found for branch \"main\"")
+	})
+
+	result, err := Detect()
+	require.NoError(t, err)
+	assert.Equal(t, "octo", result.Owner)
+	assert.Equal(t, 0, result.Number)
+}
+
+func TestDetectOutsideRepository(t *testing.T) {
+	stubRunGh(t, func(args ...string) ([]byte, error) {
+		return nil, errors.New("not a git repository")
+	})
+
+	_, err := Detect()
+	require.Error(t, err)
+	var detectErr *DetectionError
+	require.ErrorAs(t, err, &detectErr)
+	assert.Contains(t, err.Error(), "not a git repository")
+}
+
+func TestDetectPRFailureIsAnError(t *testing.T) {
+	stubRunGh(t, func(args ...string) ([]byte, error) {
+		if args[0] == "repo" {
+			return []byte(`{"name":"demo","url":"https://github.com/octo/demo","owner":{"login":"octo"}}`), nil
+		}
+		return nil, errors.New("gh pr view: HTTP 502: Bad Gateway")
+	})
+
+	_, err := Detect()
+	require.Error(t, err)
+	var detectErr *DetectionError
+	assert.False(t, errors.As(err, &detectErr), "gh failures must be distinguishable from a missing repository")
+	assert.Contains(t, err.Error(), "Bad Gateway")
+}
+
+func countingStub(t *testing.T) *int {
+	t.Helper()
+	calls := 0
+	stubRunGh(t, func(args ...string) ([]byte, error) {
+		calls++
+		if args[0] == "repo" {
+			return []byte(`{"name":"demo","url":"https://github.com/octo/demo","owner":{"login":"octo"}}`), nil
+		}
+		return []byte(`{"number":7}`), nil
+	})
+	return &calls
+}
+
+func TestDetectMemoizesPerWorkingDirectory(t *testing.T) {
+	calls := countingStub(t)
+	opts := Options{CacheDir: t.TempDir()}
+
+	first, err := DetectWithOptions(opts)
+	require.NoError(t, err)
+	second, err := DetectWithOptions(opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 2, *calls, "expected a single repo+pr lookup")
+
+	_, err = DetectWithOptions(Options{NoCache: true})
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls, "NoCache must bypass the memo")
+}
+
+func TestDetectFileCacheHonorsTTL(t *testing.T) {
+	calls := countingStub(t)
+	originalNow := now
+	defer func() { now = originalNow }()
+
+	current := time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	opts := Options{CacheDir: t.TempDir(), CacheTTL: 10 * time.Second}
+
+	_, err := DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+
+	// A new process starts with an empty memo but reuses the file cache.
+	resetMemo()
+	current = current.Add(5 * time.Second)
+	result, err := DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 7, result.Number)
+	assert.Equal(t, 2, *calls)
+
+	resetMemo()
+	current = current.Add(time.Minute)
+	_, err = DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls, "expired entries must trigger a fresh lookup")
+}
+
+func TestDetectFileCacheKeyedByBranch(t *testing.T) {
+	calls := countingStub(t)
+	checkout := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(checkout, ".git"), 0o755))
+	checkoutBranch := func(branch string) {
+		require.NoError(t, os.WriteFile(filepath.Join(checkout, ".git", "HEAD"), []byte("ref: refs/heads/"+branch+"\n"), 0o644))
+		resetMemo()
+	}
+	originalGetwd := getwd
+	defer func() { getwd = originalGetwd }()
+	getwd = func() (string, error) { return filepath.Join(checkout, "sub"), nil }
+	cacheDir := t.TempDir()
+	opts := Options{CacheDir: cacheDir}
+
+	checkoutBranch("feature")
+	_, err := DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+
+	checkoutBranch("main")
+	_, err = DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls, "switching branches must not reuse the other branch's pull request")
+
+	checkoutBranch("feature")
+	_, err = DetectWithOptions(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls, "expected the feature branch entry to still be cached")
+
+	files, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "expected no temporary files left behind")
+	assert.Equal(t, "autodetect.json", files[0].Name())
+}
+
+// stubGhScript points GH_PATH at a shell script so the real runGh is exercised.
+func stubGhScript(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs are not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "gh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+	t.Setenv(ghcli.GhPathEnv, path)
+	resetMemo()
+	t.Cleanup(resetMemo)
+}
+
+func TestDetectRepoTimeoutIsDetectionError(t *testing.T) {
+	stubGhScript(t, "exec sleep 5")
+
+	started := time.Now()
+	_, err := DetectWithOptions(Options{NoCache: true, Timeout: 100 * time.Millisecond})
+	elapsed := time.Since(started)
+
+	require.Error(t, err)
+	var detectErr *DetectionError
+	require.ErrorAs(t, err, &detectErr)
+	assert.Contains(t, err.Error(), "timed out after 100ms")
+	assert.Less(t, elapsed, 3*time.Second, "gh must be killed at the timeout")
+}
+
+func TestDetectPRTimeoutMeansNoPullRequest(t *testing.T) {
+	stubGhScript(t, `if [ "$1" = "repo" ]; then
+  echo '{"name":"demo","url":"https://github.com/octo/demo","owner":{"login":"octo"}}'
+  exit 0
+fi
+exec sleep 5`)
+
+	started := time.Now()
+	result, err := DetectWithOptions(Options{NoCache: true, Timeout: 100 * time.Millisecond})
+	elapsed := time.Since(started)
+
+	require.NoError(t, err)
+	assert.Equal(t, Result{Owner: "octo", Repo: "demo", Host: "github.com"}, result)
+	assert.Less(t, elapsed, 3*time.Second, "gh must be killed at the timeout")
+}
+
+func TestDetectContextDeadlineBoundsGh(t *testing.T) {
+	stubGhScript(t, `if [ "$1" = "repo" ]; then
+  echo '{"name":"demo","url":"https://github.com/octo/demo","owner":{"login":"octo"}}'
+  exit 0
+fi
+exec sleep 5`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := DetectContext(ctx, Options{NoCache: true})
+	elapsed := time.Since(started)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	var detectErr *DetectionError
+	assert.False(t, errors.As(err, &detectErr), "an expired context is not a detection failure")
+	assert.Less(t, elapsed, 3*time.Second, "gh must be killed at the context deadline")
+}
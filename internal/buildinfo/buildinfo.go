@@ -0,0 +1,23 @@
+// Package buildinfo exposes version metadata for the running binary.
+package buildinfo
+
+import "runtime/debug"
+
+// Version may be set at link time, e.g.
+// -ldflags "-X github.com/agynio/gh-pr-review/internal/buildinfo.Version=v1.7.0".
+var Version string
+
+// readBuildInfo is swapped in tests to simulate module metadata.
+var readBuildInfo = debug.ReadBuildInfo
+
+// ToolVersion reports the binary version, preferring the link-time Version,
+// then the main module version recorded by the Go toolchain, then "(devel)".
+func ToolVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := readBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
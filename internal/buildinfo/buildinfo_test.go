@@ -0,0 +1,30 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolVersionPrecedence(t *testing.T) {
+	originalVersion := Version
+	originalRead := readBuildInfo
+	defer func() {
+		Version = originalVersion
+		readBuildInfo = originalRead
+	}()
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "v1.6.0"}}, true
+	}
+	Version = ""
+	assert.Equal(t, "v1.6.0", ToolVersion())
+
+	Version = "v9.9.9"
+	assert.Equal(t, "v9.9.9", ToolVersion())
+
+	Version = ""
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	assert.Equal(t, "(devel)", ToolVersion())
+}
@@ -0,0 +1,37 @@
+package comments
+
+import (
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+// Comment is one entry of a review thread's conversation.
+type Comment struct {
+	CommentNodeID string `json:"comment_node_id"`
+	DatabaseID    int64  `json:"database_id"`
+	AuthorLogin   string `json:"author_login"`
+	Body          string `json:"body"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// List returns every comment in the review thread, oldest first. The thread
+// is read through threads.Service.Show; its metadata and diff hunks are
+// dropped.
+func (s *Service) List(pr resolver.Identity, threadID string) ([]Comment, error) {
+	detail, err := threads.NewService(s.API).Show(pr, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(detail.Comments))
+	for _, comment := range detail.Comments {
+		comments = append(comments, Comment{
+			CommentNodeID: comment.CommentNodeID,
+			DatabaseID:    comment.DatabaseID,
+			AuthorLogin:   comment.AuthorLogin,
+			Body:          comment.Body,
+			CreatedAt:     comment.CreatedAt,
+		})
+	}
+	return comments, nil
+}
@@ -0,0 +1,82 @@
+package comments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func listComment(id string, databaseID int64, login, createdAt string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"databaseId": databaseID,
+		"body":       "body " + id,
+		"createdAt":  createdAt,
+		"diffHunk":   "@@ -1 +1 @@",
+		"author":     map[string]interface{}{"login": login},
+	}
+}
+
+func TestServiceList_PaginatesThreadComments(t *testing.T) {
+	calls := 0
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "query ThreadShow")
+		assert.Equal(t, "PRRT_thread", variables["id"])
+		calls++
+		switch calls {
+		case 1:
+			assert.NotContains(t, variables, "after")
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{
+				"id":   "PRRT_thread",
+				"path": "main.go",
+				"comments": map[string]interface{}{
+					"nodes":    []interface{}{listComment("PRRC_1", 11, "alice", "2025-12-03T10:00:00Z"), listComment("PRRC_2", 12, "bob", "2025-12-03T11:00:00Z")},
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "page-1"},
+				},
+			}})
+		case 2:
+			assert.Equal(t, "page-1", variables["after"])
+			ghost := listComment("PRRC_3", 13, "", "2025-12-04T09:00:00Z")
+			ghost["author"] = nil
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{
+				"id":   "PRRT_thread",
+				"path": "main.go",
+				"comments": map[string]interface{}{
+					"nodes":    []interface{}{ghost},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}})
+		default:
+			t.Fatalf("unexpected extra page request")
+			return nil
+		}
+	}
+
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	comments, err := NewService(api).List(pr, " PRRT_thread ")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []Comment{
+		{CommentNodeID: "PRRC_1", DatabaseID: 11, AuthorLogin: "alice", Body: "body PRRC_1", CreatedAt: "2025-12-03T10:00:00Z"},
+		{CommentNodeID: "PRRC_2", DatabaseID: 12, AuthorLogin: "bob", Body: "body PRRC_2", CreatedAt: "2025-12-03T11:00:00Z"},
+		{CommentNodeID: "PRRC_3", DatabaseID: 13, Body: "body PRRC_3", CreatedAt: "2025-12-04T09:00:00Z"},
+	}, comments)
+}
+
+func TestServiceList_ThreadNotFound(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{"node": nil})
+	}
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	_, err := NewService(api).List(pr, "PRRT_missing")
+	assert.EqualError(t, err, "thread PRRT_missing not found on github.com")
+
+	_, err = NewService(api).List(pr, "  ")
+	assert.EqualError(t, err, "thread id is required")
+}
@@ -2,6 +2,7 @@ package comments
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/agynio/gh-pr-review/internal/ghcli"
@@ -30,7 +31,10 @@ const commentDetailsQuery = `query PullRequestReviewCommentDetails($id: ID!) {
       url
       createdAt
       updatedAt
-      author { login }
+      author {
+        login
+        ... on User { databaseId }
+      }
       pullRequestReview { id databaseId state }
       replyTo { id }
     }
@@ -47,6 +51,17 @@ const threadDetailsQuery = `query PullRequestReviewThreadDetails($id: ID!) {
   }
 }`
 
+const threadLatestCommentQuery = `query PullRequestReviewThreadLatestComment($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      id
+      comments(last: 1) {
+        nodes { id }
+      }
+    }
+  }
+}`
+
 // Service provides high-level review comment operations.
 type Service struct {
 	API ghcli.API
@@ -57,6 +72,10 @@ type ReplyOptions struct {
 	ThreadID string
 	ReviewID string
 	Body     string
+	// Quote prepends the thread's most recent comment to Body as a blockquote.
+	Quote bool
+	// QuoteAuthor adds an "@author wrote:" line to the quote.
+	QuoteAuthor bool
 }
 
 // Reply represents the normalized GraphQL response after adding a thread reply.
@@ -75,6 +94,7 @@ type Reply struct {
 	Path             string  `json:"path"`
 	HtmlURL          string  `json:"html_url"`
 	AuthorLogin      string  `json:"author_login"`
+	AuthorID         *int64  `json:"author_id,omitempty"`
 	CreatedAt        string  `json:"created_at"`
 	UpdatedAt        string  `json:"updated_at"`
 }
@@ -89,7 +109,8 @@ type commentDetails struct {
 	CreatedAt  string  `json:"createdAt"`
 	UpdatedAt  string  `json:"updatedAt"`
 	Author     *struct {
-		Login string `json:"login"`
+		Login      string `json:"login"`
+		DatabaseID *int64 `json:"databaseId"`
 	} `json:"author"`
 	PullRequestReview *struct {
 		ID         string `json:"id"`
@@ -122,9 +143,18 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 		return Reply{}, errors.New("reply body is required")
 	}
 
+	body := opts.Body
+	if opts.Quote {
+		quote, err := s.quoteLatestComment(threadID, opts.QuoteAuthor)
+		if err != nil {
+			return Reply{}, err
+		}
+		body = quote + "\n\n" + body
+	}
+
 	input := map[string]interface{}{
 		"pullRequestReviewThreadId": threadID,
-		"body":                      opts.Body,
+		"body":                      body,
 	}
 	if reviewID := strings.TrimSpace(opts.ReviewID); reviewID != "" {
 		input["pullRequestReviewId"] = reviewID
@@ -163,6 +193,9 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	if err != nil {
 		return Reply{}, err
 	}
+	if commentDetails.Author == nil || strings.TrimSpace(commentDetails.Author.Login) == "" {
+		return Reply{}, errors.New("comment details missing author")
+	}
 
 	threadDetails, err := s.loadThreadDetails(threadID)
 	if err != nil {
@@ -178,6 +211,7 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 		Path:             commentDetails.Path,
 		HtmlURL:          commentDetails.URL,
 		AuthorLogin:      commentDetails.Author.Login,
+		AuthorID:         commentDetails.Author.DatabaseID,
 		CreatedAt:        commentDetails.CreatedAt,
 		UpdatedAt:        commentDetails.UpdatedAt,
 	}
@@ -212,6 +246,63 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	return reply, nil
 }
 
+// quoteLatestComment renders the most recent comment of the thread as a
+// Markdown blockquote, optionally attributed to its author ("ghost" when the
+// account was deleted).
+func (s *Service) quoteLatestComment(threadID string, withAuthor bool) (string, error) {
+	var response struct {
+		Node *struct {
+			ID       string `json:"id"`
+			Comments struct {
+				Nodes []struct {
+					ID string `json:"id"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(threadLatestCommentQuery, map[string]interface{}{"id": threadID}, &response); err != nil {
+		return "", err
+	}
+	if response.Node == nil || strings.TrimSpace(response.Node.ID) == "" {
+		return "", errors.New("failed to load thread details")
+	}
+	if len(response.Node.Comments.Nodes) == 0 {
+		return "", fmt.Errorf("thread %s has no comment to quote", threadID)
+	}
+
+	latest, err := s.loadCommentDetails(response.Node.Comments.Nodes[0].ID)
+	if err != nil {
+		return "", err
+	}
+	author := ""
+	if withAuthor {
+		// Comments by deleted accounts have no author; GitHub shows them as ghost.
+		author = "ghost"
+		if latest.Author != nil && strings.TrimSpace(latest.Author.Login) != "" {
+			author = latest.Author.Login
+		}
+	}
+	return QuoteBody(latest.Body, author), nil
+}
+
+// QuoteBody prefixes every line of body with "> ". A non-empty author adds a
+// leading "> @author wrote:" line.
+func QuoteBody(body, author string) string {
+	var lines []string
+	if author != "" {
+		lines = append(lines, fmt.Sprintf("> @%s wrote:", author))
+	}
+	trimmed := strings.TrimRight(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for _, line := range strings.Split(trimmed, "\n") {
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, ">")
+			continue
+		}
+		lines = append(lines, "> "+line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *Service) loadCommentDetails(id string) (commentDetails, error) {
 	variables := map[string]interface{}{"id": id}
 	var response struct {
@@ -223,9 +314,6 @@ func (s *Service) loadCommentDetails(id string) (commentDetails, error) {
 	if response.Node == nil || strings.TrimSpace(response.Node.ID) == "" {
 		return commentDetails{}, errors.New("failed to load comment details")
 	}
-	if response.Node.Author == nil || strings.TrimSpace(response.Node.Author.Login) == "" {
-		return commentDetails{}, errors.New("comment details missing author")
-	}
 	return *response.Node, nil
 }
 
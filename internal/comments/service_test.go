@@ -285,3 +285,56 @@ func TestServiceReply_ErrorsOnMissingThread(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to load thread details")
 }
+
+func TestServiceReply_QuotesLatestComment(t *testing.T) {
+	for name, tc := range map[string]struct {
+		withAuthor bool
+		author     interface{}
+		want       string
+	}{
+		"plain":                   {false, map[string]interface{}{"login": "alice"}, "> Please rename\n>\n> > nested\n\nDone."},
+		"with author":             {true, map[string]interface{}{"login": "alice"}, "> @alice wrote:\n> Please rename\n>\n> > nested\n\nDone."},
+		"deleted author":          {true, nil, "> @ghost wrote:\n> Please rename\n>\n> > nested\n\nDone."},
+		"deleted, no attribution": {false, nil, "> Please rename\n>\n> > nested\n\nDone."},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var sent string
+			api := &fakeAPI{}
+			api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				switch {
+				case strings.Contains(query, "PullRequestReviewThreadLatestComment"):
+					require.Equal(t, "PRRT_thread", variables["id"])
+					return assign(result, map[string]interface{}{"node": map[string]interface{}{
+						"id":       "PRRT_thread",
+						"comments": map[string]interface{}{"nodes": []map[string]interface{}{{"id": "PRRC_latest"}}},
+					}})
+				case strings.Contains(query, "PullRequestReviewCommentDetails"):
+					body, author := "Please rename\r\n\r\n> nested\n", tc.author
+					if variables["id"] == "PRRC_reply" {
+						body, author = sent, map[string]interface{}{"login": "octocat"}
+					}
+					return assign(result, map[string]interface{}{"node": map[string]interface{}{
+						"id":     variables["id"],
+						"body":   body,
+						"author": author,
+					}})
+				case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+					sent = variables["input"].(map[string]interface{})["body"].(string)
+					return assign(result, map[string]interface{}{"addPullRequestReviewThreadReply": map[string]interface{}{
+						"comment": map[string]interface{}{"id": "PRRC_reply", "body": sent, "author": map[string]interface{}{"login": "octocat"}},
+					}})
+				case strings.Contains(query, "PullRequestReviewThreadDetails"):
+					return assign(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRT_thread"}})
+				default:
+					t.Fatalf("unexpected query: %s", query)
+					return nil
+				}
+			}
+
+			reply, err := NewService(api).Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Done.", Quote: true, QuoteAuthor: tc.withAuthor})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, sent)
+			assert.Equal(t, tc.want, reply.Body)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package comments
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+const suggestionCommentQuery = `query SuggestionComment($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReviewComment {
+      id
+      databaseId
+      body
+      path
+      line
+      startLine
+      outdated
+      repository { name owner { login } }
+      pullRequest {
+        number
+        headRefName
+        headRefOid
+        headRepository { name owner { login } }
+      }
+    }
+  }
+}`
+
+// DefaultSuggestionMessage is the commit message used when none is given.
+const DefaultSuggestionMessage = "Apply suggestion from code review"
+
+// AppliedSuggestion describes the commit created by ApplySuggestion.
+type AppliedSuggestion struct {
+	CommentNodeID string `json:"comment_node_id"`
+	Path          string `json:"path"`
+	Branch        string `json:"branch"`
+	CommitSHA     string `json:"commit_sha"`
+}
+
+var suggestionFenceRE = regexp.MustCompile("^(`{3,})suggestion\\s*$")
+
+// ApplySuggestion commits the suggested change from a single review comment to
+// the pull request's head branch. The file is read at the head commit and
+// written back through the contents API with its blob SHA, so GitHub rejects
+// the write if the branch moved in between. Outdated comments and comments on
+// the left side of the diff are refused because their lines do not map onto
+// the head commit.
+func (s *Service) ApplySuggestion(pr resolver.Identity, commentID, message string) (*AppliedSuggestion, error) {
+	commentID = strings.TrimSpace(commentID)
+	if commentID == "" {
+		return nil, errors.New("comment id is required")
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = DefaultSuggestionMessage
+	}
+
+	var response struct {
+		Node *struct {
+			ID         string `json:"id"`
+			DatabaseID int64  `json:"databaseId"`
+			Body       string `json:"body"`
+			Path       string `json:"path"`
+			Line       *int   `json:"line"`
+			StartLine  *int   `json:"startLine"`
+			Outdated   bool   `json:"outdated"`
+			Repository struct {
+				Name  string `json:"name"`
+				Owner struct {
+					Login string `json:"login"`
+				} `json:"owner"`
+			} `json:"repository"`
+			PullRequest struct {
+				Number         int    `json:"number"`
+				HeadRefName    string `json:"headRefName"`
+				HeadRefOid     string `json:"headRefOid"`
+				HeadRepository *struct {
+					Name  string `json:"name"`
+					Owner struct {
+						Login string `json:"login"`
+					} `json:"owner"`
+				} `json:"headRepository"`
+			} `json:"pullRequest"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(suggestionCommentQuery, map[string]interface{}{"id": commentID}, &response); err != nil {
+		return nil, err
+	}
+	comment := response.Node
+	if comment == nil || strings.TrimSpace(comment.ID) == "" {
+		return nil, fmt.Errorf("comment %s not found", commentID)
+	}
+	repo := comment.Repository
+	if pr.Owner != "" && (!strings.EqualFold(repo.Owner.Login, pr.Owner) || !strings.EqualFold(repo.Name, pr.Repo)) {
+		return nil, fmt.Errorf("comment %s belongs to %s/%s, not %s/%s", commentID, repo.Owner.Login, repo.Name, pr.Owner, pr.Repo)
+	}
+	if pr.Number != 0 && comment.PullRequest.Number != pr.Number {
+		return nil, fmt.Errorf("comment %s belongs to pull request #%d, not #%d", commentID, comment.PullRequest.Number, pr.Number)
+	}
+	if comment.Outdated || comment.Line == nil {
+		return nil, fmt.Errorf("comment %s is outdated; its suggestion no longer applies to the head commit", commentID)
+	}
+	suggestion, err := ExtractSuggestion(comment.Body)
+	if err != nil {
+		return nil, fmt.Errorf("comment %s: %w", commentID, err)
+	}
+	// GraphQL does not expose a comment's diff side, so read it over REST.
+	var sides struct {
+		Side      string  `json:"side"`
+		StartSide *string `json:"start_side"`
+	}
+	sidesPath := fmt.Sprintf("repos/%s/%s/pulls/comments/%d", repo.Owner.Login, repo.Name, comment.DatabaseID)
+	if err := s.API.REST("GET", sidesPath, nil, nil, &sides); err != nil {
+		return nil, fmt.Errorf("read comment %s: %w", commentID, err)
+	}
+	if strings.EqualFold(sides.Side, "LEFT") || (sides.StartSide != nil && strings.EqualFold(*sides.StartSide, "LEFT")) {
+		return nil, fmt.Errorf("comment %s is on the left side of the diff; only suggestions on the new code can be applied", commentID)
+	}
+	head := comment.PullRequest.HeadRepository
+	if head == nil {
+		return nil, fmt.Errorf("head repository of pull request #%d is unavailable", comment.PullRequest.Number)
+	}
+
+	endLine := *comment.Line
+	startLine := endLine
+	if comment.StartLine != nil {
+		startLine = *comment.StartLine
+	}
+
+	segments := strings.Split(comment.Path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	contentsPath := fmt.Sprintf("repos/%s/%s/contents/%s", head.Owner.Login, head.Name, strings.Join(segments, "/"))
+	var file struct {
+		SHA      string `json:"sha"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := s.API.REST("GET", contentsPath, map[string]string{"ref": comment.PullRequest.HeadRefOid}, nil, &file); err != nil {
+		return nil, fmt.Errorf("read %s: %w", comment.Path, err)
+	}
+	if file.Encoding != "base64" {
+		return nil, fmt.Errorf("read %s: unsupported content encoding %q", comment.Path, file.Encoding)
+	}
+	original, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", comment.Path, err)
+	}
+
+	updated, err := replaceLines(string(original), startLine, endLine, suggestion)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", comment.Path, err)
+	}
+
+	payload := map[string]interface{}{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString([]byte(updated)),
+		"sha":     file.SHA,
+		"branch":  comment.PullRequest.HeadRefName,
+	}
+	var written struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := s.API.REST("PUT", contentsPath, nil, payload, &written); err != nil {
+		return nil, fmt.Errorf("commit suggestion to %s: %w", comment.PullRequest.HeadRefName, err)
+	}
+	if strings.TrimSpace(written.Commit.SHA) == "" {
+		return nil, errors.New("contents update returned no commit sha")
+	}
+
+	return &AppliedSuggestion{
+		CommentNodeID: comment.ID,
+		Path:          comment.Path,
+		Branch:        comment.PullRequest.HeadRefName,
+		CommitSHA:     written.Commit.SHA,
+	}, nil
+}
+
+// ExtractSuggestion returns the replacement text of the single ```suggestion
+// block in body. An empty block, which suggests deleting the lines, yields "".
+func ExtractSuggestion(body string) (string, error) {
+	var (
+		blocks  []string
+		fence   string
+		current []string
+		open    bool
+	)
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !open {
+			if match := suggestionFenceRE.FindStringSubmatch(trimmed); match != nil {
+				fence, current, open = match[1], nil, true
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == "" {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			open = false
+			continue
+		}
+		current = append(current, line)
+	}
+
+	switch len(blocks) {
+	case 0:
+		return "", errors.New("no suggestion block found")
+	case 1:
+		return blocks[0], nil
+	default:
+		return "", fmt.Errorf("found %d suggestion blocks; only single suggestions can be applied", len(blocks))
+	}
+}
+
+// replaceLines swaps the 1-based inclusive line range start..end of content
+// for replacement, keeping the file's trailing-newline convention.
+func replaceLines(content string, start, end int, replacement string) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if start < 1 || start > end {
+		return "", fmt.Errorf("invalid line range %d-%d", start, end)
+	}
+	if end > len(lines) {
+		return "", fmt.Errorf("line %d is past the end of the file (%d lines)", end, len(lines))
+	}
+
+	var b strings.Builder
+	for _, line := range lines[:start-1] {
+		b.WriteString(line)
+	}
+	if replacement != "" {
+		b.WriteString(replacement)
+		b.WriteString("\n")
+	}
+	for _, line := range lines[end:] {
+		b.WriteString(line)
+	}
+
+	updated := b.String()
+	if !strings.HasSuffix(content, "\n") && end == len(lines) {
+		updated = strings.TrimSuffix(updated, "\n")
+	}
+	return updated, nil
+}
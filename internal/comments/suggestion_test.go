@@ -0,0 +1,162 @@
+package comments
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func suggestionComment(body string, startLine, line interface{}, outdated bool) map[string]interface{} {
+	return map[string]interface{}{"node": map[string]interface{}{
+		"id":         "PRRC_suggest",
+		"databaseId": 99,
+		"body":       body,
+		"path":       "pkg/calc.go",
+		"line":       line,
+		"startLine":  startLine,
+		"outdated":   outdated,
+		"repository": map[string]interface{}{
+			"name":  "demo",
+			"owner": map[string]interface{}{"login": "octo"},
+		},
+		"pullRequest": map[string]interface{}{
+			"number":      7,
+			"headRefName": "feature",
+			"headRefOid":  "abc123",
+			"headRepository": map[string]interface{}{
+				"name":  "demo-fork",
+				"owner": map[string]interface{}{"login": "casey"},
+			},
+		},
+	}}
+}
+
+func TestServiceApplySuggestionCommitsToHeadBranch(t *testing.T) {
+	original := "package calc\n\nfunc Add(a, b int) int {\n\treturn a - b\n}\n"
+	body := "Typo:\n\n```suggestion\nfunc Add(a, b int) int {\n\treturn a + b\n```"
+
+	var put map[string]interface{}
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Equal(t, suggestionCommentQuery, query)
+		assert.Equal(t, "PRRC_suggest", variables["id"])
+		return assign(result, suggestionComment(body, 3, 4, false))
+	}
+	api.restFunc = func(method, path string, params map[string]string, payload interface{}, result interface{}) error {
+		if path == "repos/octo/demo/pulls/comments/99" {
+			return assign(result, map[string]interface{}{"side": "RIGHT", "start_side": "RIGHT"})
+		}
+		require.Equal(t, "repos/casey/demo-fork/contents/pkg/calc.go", path)
+		switch method {
+		case "GET":
+			assert.Equal(t, map[string]string{"ref": "abc123"}, params)
+			encoded := base64.StdEncoding.EncodeToString([]byte(original))
+			return assign(result, map[string]interface{}{
+				"sha":      "blob-sha",
+				"encoding": "base64",
+				"content":  encoded[:10] + "\n" + encoded[10:],
+			})
+		case "PUT":
+			put = payload.(map[string]interface{})
+			return assign(result, map[string]interface{}{"commit": map[string]interface{}{"sha": "commit-sha"}})
+		default:
+			t.Fatalf("unexpected method %s", method)
+			return nil
+		}
+	}
+
+	applied, err := NewService(api).ApplySuggestion(resolver.Identity{Owner: "Octo", Repo: "Demo", Number: 7}, " PRRC_suggest ", "")
+	require.NoError(t, err)
+	assert.Equal(t, &AppliedSuggestion{CommentNodeID: "PRRC_suggest", Path: "pkg/calc.go", Branch: "feature", CommitSHA: "commit-sha"}, applied)
+
+	require.NotNil(t, put)
+	assert.Equal(t, DefaultSuggestionMessage, put["message"])
+	assert.Equal(t, "blob-sha", put["sha"])
+	assert.Equal(t, "feature", put["branch"])
+	content, err := base64.StdEncoding.DecodeString(put["content"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n", string(content))
+}
+
+func TestServiceApplySuggestionRejectsUnusableComments(t *testing.T) {
+	otherRepo := suggestionComment("```suggestion\nx\n```", nil, 4, false)
+	otherRepo["node"].(map[string]interface{})["repository"] = map[string]interface{}{
+		"name":  "demo",
+		"owner": map[string]interface{}{"login": "someone-else"},
+	}
+	for name, tc := range map[string]struct {
+		comment map[string]interface{}
+		pr      int
+		side    string
+		want    string
+	}{
+		"outdated":       {suggestionComment("```suggestion\nx\n```", nil, nil, true), 7, "", "comment PRRC_suggest is outdated; its suggestion no longer applies to the head commit"},
+		"no suggestion":  {suggestionComment("Looks off", nil, 4, false), 7, "", "comment PRRC_suggest: no suggestion block found"},
+		"two blocks":     {suggestionComment("```suggestion\na\n```\n```suggestion\nb\n```", nil, 4, false), 7, "", "comment PRRC_suggest: found 2 suggestion blocks; only single suggestions can be applied"},
+		"other pr":       {suggestionComment("```suggestion\nx\n```", nil, 4, false), 8, "", "comment PRRC_suggest belongs to pull request #7, not #8"},
+		"other repo":     {otherRepo, 7, "", "comment PRRC_suggest belongs to someone-else/demo, not octo/demo"},
+		"left side":      {suggestionComment("```suggestion\nx\n```", nil, 4, false), 7, "LEFT", "comment PRRC_suggest is on the left side of the diff; only suggestions on the new code can be applied"},
+		"missing object": {map[string]interface{}{"node": nil}, 7, "", "comment PRRC_suggest not found"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeAPI{}
+			api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				return assign(result, tc.comment)
+			}
+			api.restFunc = func(method, path string, params map[string]string, payload interface{}, result interface{}) error {
+				require.Equal(t, "repos/octo/demo/pulls/comments/99", path)
+				return assign(result, map[string]interface{}{"side": tc.side})
+			}
+
+			_, err := NewService(api).ApplySuggestion(resolver.Identity{Owner: "octo", Repo: "demo", Number: tc.pr}, "PRRC_suggest", "")
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+}
+
+func TestServiceApplySuggestionEscapesContentsPath(t *testing.T) {
+	comment := suggestionComment("```suggestion\nb\n```", nil, 1, false)
+	comment["node"].(map[string]interface{})["path"] = "docs/release notes#1.md"
+
+	var paths []string
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, comment)
+	}
+	api.restFunc = func(method, path string, params map[string]string, payload interface{}, result interface{}) error {
+		paths = append(paths, method+" "+path)
+		switch {
+		case method == "GET" && path == "repos/octo/demo/pulls/comments/99":
+			return assign(result, map[string]interface{}{"side": "RIGHT"})
+		case method == "GET":
+			return assign(result, map[string]interface{}{"sha": "blob-sha", "encoding": "base64", "content": base64.StdEncoding.EncodeToString([]byte("a\n"))})
+		default:
+			return assign(result, map[string]interface{}{"commit": map[string]interface{}{"sha": "commit-sha"}})
+		}
+	}
+
+	_, err := NewService(api).ApplySuggestion(resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, "PRRC_suggest", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET repos/octo/demo/pulls/comments/99",
+		"GET repos/casey/demo-fork/contents/docs/release%20notes%231.md",
+		"PUT repos/casey/demo-fork/contents/docs/release%20notes%231.md",
+	}, paths)
+}
+
+func TestReplaceLines(t *testing.T) {
+	updated, err := replaceLines("a\nb\nc", 3, 3, "C")
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nC", updated, "a missing trailing newline is preserved")
+
+	updated, err = replaceLines("a\nb\nc\n", 2, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a\nc\n", updated, "an empty suggestion deletes the lines")
+
+	_, err = replaceLines("a\n", 1, 2, "x")
+	assert.EqualError(t, err, "line 2 is past the end of the file (1 lines)")
+}
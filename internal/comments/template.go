@@ -0,0 +1,80 @@
+package comments
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const threadContextQuery = `query PullRequestReviewThreadContext($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      id
+      path
+      line
+      comments(first: 1) {
+        nodes {
+          author { login }
+        }
+      }
+    }
+  }
+}`
+
+// ParseBodyTemplate parses a reply body written as a Go text/template.
+// Referencing a variable that is neither built in nor supplied fails at
+// render time instead of printing "<no value>".
+func ParseBodyTemplate(text string) (*template.Template, error) {
+	return template.New("body").Option("missingkey=error").Parse(text)
+}
+
+// RenderBodyTemplate executes tmpl against the thread's context: thread_id,
+// path, line (empty for file-level threads), and author, the login of the
+// comment that started the thread. Entries in vars are added alongside and
+// win over the built-in names.
+func (s *Service) RenderBodyTemplate(threadID string, tmpl *template.Template, vars map[string]string) (string, error) {
+	var response struct {
+		Node *struct {
+			ID       string `json:"id"`
+			Path     string `json:"path"`
+			Line     *int   `json:"line"`
+			Comments struct {
+				Nodes []struct {
+					Author *struct {
+						Login string `json:"login"`
+					} `json:"author"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(threadContextQuery, map[string]interface{}{"id": threadID}, &response); err != nil {
+		return "", err
+	}
+	thread := response.Node
+	if thread == nil || strings.TrimSpace(thread.ID) == "" {
+		return "", errors.New("failed to load thread details")
+	}
+
+	data := map[string]string{
+		"thread_id": thread.ID,
+		"path":      thread.Path,
+		"line":      "",
+		"author":    "",
+	}
+	if thread.Line != nil {
+		data["line"] = strconv.Itoa(*thread.Line)
+	}
+	if len(thread.Comments.Nodes) > 0 && thread.Comments.Nodes[0].Author != nil {
+		data["author"] = thread.Comments.Nodes[0].Author.Login
+	}
+	for key, value := range vars {
+		data[key] = value
+	}
+
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
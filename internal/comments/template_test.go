@@ -0,0 +1,45 @@
+package comments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRenderBodyTemplate(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Equal(t, threadContextQuery, query)
+		require.Equal(t, "PRRT_thread", variables["id"])
+		return assign(result, map[string]interface{}{"node": map[string]interface{}{
+			"id":   "PRRT_thread",
+			"path": "internal/service.go",
+			"line": 42,
+			"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+				{"author": map[string]interface{}{"login": "alice"}},
+			}},
+		}})
+	}
+	service := NewService(api)
+
+	tmpl, err := ParseBodyTemplate("Thanks @{{.author}}, fixed {{.path}}:{{.line}} in {{.commit}} ({{.thread_id}}).")
+	require.NoError(t, err)
+	body, err := service.RenderBodyTemplate("PRRT_thread", tmpl, map[string]string{"commit": "abc123"})
+	require.NoError(t, err)
+	assert.Equal(t, "Thanks @alice, fixed internal/service.go:42 in abc123 (PRRT_thread).", body)
+
+	override, err := service.RenderBodyTemplate("PRRT_thread", tmpl, map[string]string{"commit": "abc123", "author": "team"})
+	require.NoError(t, err)
+	assert.Equal(t, "Thanks @team, fixed internal/service.go:42 in abc123 (PRRT_thread).", override)
+
+	_, err = service.RenderBodyTemplate("PRRT_thread", tmpl, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `map has no entry for key "commit"`)
+}
+
+func TestParseBodyTemplateRejectsInvalidSyntax(t *testing.T) {
+	_, err := ParseBodyTemplate("Thanks {{.author")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unclosed action")
+}
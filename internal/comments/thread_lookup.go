@@ -0,0 +1,143 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+const threadCommentIDsQuery = `query ReviewThreadCommentIDs($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes {
+          id
+          comments(first: 100) {
+            nodes { databaseId }
+            pageInfo { hasNextPage endCursor }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const threadMoreCommentIDsQuery = `query ReviewThreadMoreCommentIDs($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: 100, after: $cursor) {
+        nodes { databaseId }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+type commentIDPage struct {
+	Nodes []struct {
+		DatabaseID int64 `json:"databaseId"`
+	} `json:"nodes"`
+	PageInfo pageInfo `json:"pageInfo"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+func (p commentIDPage) contains(id int64) bool {
+	for _, node := range p.Nodes {
+		if node.DatabaseID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ThreadForComment returns the node ID of the review thread containing the
+// review comment with the given REST (database) ID.
+func (s *Service) ThreadForComment(pr resolver.Identity, commentDatabaseID int64) (string, error) {
+	if commentDatabaseID <= 0 {
+		return "", errors.New("comment id must be positive")
+	}
+
+	var cursor *string
+	for {
+		variables := map[string]interface{}{
+			"owner":  pr.Owner,
+			"name":   pr.Repo,
+			"number": pr.Number,
+		}
+		if cursor != nil {
+			variables["cursor"] = *cursor
+		}
+
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							ID       string        `json:"id"`
+							Comments commentIDPage `json:"comments"`
+						} `json:"nodes"`
+						PageInfo pageInfo `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(threadCommentIDsQuery, variables, &response); err != nil {
+			return "", err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return "", &resolver.NotFoundError{Identity: pr}
+		}
+
+		threads := response.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			found, err := s.threadContainsComment(thread.ID, thread.Comments, commentDatabaseID)
+			if err != nil {
+				return "", err
+			}
+			if found {
+				return thread.ID, nil
+			}
+		}
+
+		if !threads.PageInfo.HasNextPage || threads.PageInfo.EndCursor == "" {
+			break
+		}
+		next := threads.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	return "", fmt.Errorf("review comment %d not found on %s", commentDatabaseID, pr.URL())
+}
+
+// threadContainsComment checks the first comment page already fetched for a
+// thread and, when the thread has more comments, pages through the rest.
+func (s *Service) threadContainsComment(threadID string, page commentIDPage, commentDatabaseID int64) (bool, error) {
+	for {
+		if page.contains(commentDatabaseID) {
+			return true, nil
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return false, nil
+		}
+
+		variables := map[string]interface{}{"id": threadID, "cursor": page.PageInfo.EndCursor}
+		var response struct {
+			Node *struct {
+				Comments commentIDPage `json:"comments"`
+			} `json:"node"`
+		}
+		if err := s.API.GraphQL(threadMoreCommentIDsQuery, variables, &response); err != nil {
+			return false, err
+		}
+		if response.Node == nil {
+			return false, fmt.Errorf("thread %s not found", threadID)
+		}
+		page = response.Node.Comments
+	}
+}
@@ -0,0 +1,98 @@
+package comments
+
+import (
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func idNodes(ids ...int64) []map[string]interface{} {
+	nodes := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, map[string]interface{}{"databaseId": id})
+	}
+	return nodes
+}
+
+func TestServiceThreadForComment_FindsCommentOnLaterPages(t *testing.T) {
+	var queries []string
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch query {
+		case threadCommentIDsQuery:
+			assert.Equal(t, "octo", variables["owner"])
+			assert.Equal(t, "demo", variables["name"])
+			assert.Equal(t, 9, variables["number"])
+			if _, ok := variables["cursor"]; !ok {
+				queries = append(queries, "threads:1")
+				return assign(result, map[string]interface{}{
+					"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{{
+							"id":       "PRRT_first",
+							"comments": map[string]interface{}{"nodes": idNodes(1, 2), "pageInfo": map[string]interface{}{"hasNextPage": false}},
+						}},
+						"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "threads-1"},
+					}}},
+				})
+			}
+			assert.Equal(t, "threads-1", variables["cursor"])
+			queries = append(queries, "threads:2")
+			return assign(result, map[string]interface{}{
+				"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviewThreads": map[string]interface{}{
+					"nodes": []map[string]interface{}{{
+						"id":       "PRRT_second",
+						"comments": map[string]interface{}{"nodes": idNodes(3, 4), "pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "comments-1"}},
+					}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				}}},
+			})
+		case threadMoreCommentIDsQuery:
+			assert.Equal(t, "PRRT_second", variables["id"])
+			assert.Equal(t, "comments-1", variables["cursor"])
+			queries = append(queries, "comments:2")
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"comments": map[string]interface{}{"nodes": idNodes(5, 123456), "pageInfo": map[string]interface{}{"hasNextPage": false}},
+				},
+			})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+
+	svc := NewService(api)
+	threadID, err := svc.ThreadForComment(resolver.Identity{Owner: "octo", Repo: "demo", Number: 9, Host: "github.com"}, 123456)
+	require.NoError(t, err)
+	assert.Equal(t, "PRRT_second", threadID)
+	assert.Equal(t, []string{"threads:1", "threads:2", "comments:2"}, queries)
+}
+
+func TestServiceThreadForComment_NotFound(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{
+			"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviewThreads": map[string]interface{}{
+				"nodes": []map[string]interface{}{{
+					"id":       "PRRT_only",
+					"comments": map[string]interface{}{"nodes": idNodes(1), "pageInfo": map[string]interface{}{"hasNextPage": false}},
+				}},
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+			}}},
+		})
+	}
+
+	svc := NewService(api)
+	_, err := svc.ThreadForComment(resolver.Identity{Owner: "octo", Repo: "demo", Number: 9, Host: "github.com"}, 77)
+	require.Error(t, err)
+	assert.EqualError(t, err, "review comment 77 not found on https://github.com/octo/demo/pull/9")
+}
+
+func TestServiceThreadForComment_RejectsNonPositiveID(t *testing.T) {
+	svc := NewService(&fakeAPI{})
+	_, err := svc.ThreadForComment(resolver.Identity{}, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comment id must be positive")
+}
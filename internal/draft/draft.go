@@ -0,0 +1,156 @@
+// Package draft stores inline review comments locally until a pending review
+// exists to post them into.
+package draft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// DirEnv names the environment variable that overrides the draft directory.
+const DirEnv = "GH_PR_REVIEW_DRAFT_DIR"
+
+// Draft is an inline comment saved before its pending review was opened.
+type Draft struct {
+	Path      string    `json:"path"`
+	Line      int       `json:"line"`
+	Side      string    `json:"side"`
+	StartLine *int      `json:"start_line,omitempty"`
+	StartSide *string   `json:"start_side,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store keeps drafts in one JSON file per pull request under Dir.
+type Store struct {
+	Dir string
+}
+
+// NewStore returns a store rooted at dir, falling back to $GH_PR_REVIEW_DRAFT_DIR
+// and then to gh-pr-review/drafts in the user's config directory.
+func NewStore(dir string) (*Store, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		dir = strings.TrimSpace(os.Getenv(DirEnv))
+	}
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("locate draft directory: %w", err)
+		}
+		dir = filepath.Join(configDir, "gh-pr-review", "drafts")
+	}
+	return &Store{Dir: dir}, nil
+}
+
+// File returns the path of the draft file for pr.
+func (s *Store) File(pr resolver.Identity) string {
+	host := strings.ToLower(strings.TrimSpace(pr.Host))
+	if host == "" {
+		host = "github.com"
+	}
+	return filepath.Join(s.Dir, host, pr.Owner, pr.Repo, fmt.Sprintf("%d.json", pr.Number))
+}
+
+// List returns the drafts saved for pr in the order they were added.
+func (s *Store) List(pr resolver.Identity) ([]Draft, error) {
+	data, err := os.ReadFile(s.File(pr))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Draft{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read drafts: %w", err)
+	}
+	drafts := []Draft{}
+	if err := json.Unmarshal(data, &drafts); err != nil {
+		return nil, fmt.Errorf("parse drafts in %s: %w", s.File(pr), err)
+	}
+	return drafts, nil
+}
+
+// Add appends d to the drafts for pr and returns the updated list.
+func (s *Store) Add(pr resolver.Identity, d Draft) ([]Draft, error) {
+	if strings.TrimSpace(d.Path) == "" {
+		return nil, errors.New("path is required")
+	}
+	if d.Line <= 0 {
+		return nil, errors.New("line must be positive")
+	}
+	if strings.TrimSpace(d.Body) == "" {
+		return nil, errors.New("body is required")
+	}
+
+	drafts, err := s.List(pr)
+	if err != nil {
+		return nil, err
+	}
+	drafts = append(drafts, d)
+	if err := s.save(pr, drafts); err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// FlushResult records the outcome of posting one draft.
+type FlushResult struct {
+	Draft Draft
+	Err   error
+}
+
+// Flush posts every draft for pr with post. Drafts that post successfully are
+// removed; failed ones stay in the store so a later flush can retry them, and
+// the file is deleted once nothing remains. The store is rewritten after each
+// successful post, so an interrupted flush never posts a draft twice; if that
+// write fails, Flush stops and returns the results so far. Results follow the
+// stored order.
+func (s *Store) Flush(pr resolver.Identity, post func(Draft) error) ([]FlushResult, error) {
+	drafts, err := s.List(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FlushResult, 0, len(drafts))
+	failed := make([]Draft, 0)
+	for i, d := range drafts {
+		results = append(results, FlushResult{Draft: d, Err: post(d)})
+		if results[i].Err != nil {
+			failed = append(failed, d)
+			continue
+		}
+		remaining := append(append([]Draft{}, failed...), drafts[i+1:]...)
+		if err := s.save(pr, remaining); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// save writes drafts for pr, removing the file when the list is empty.
+func (s *Store) save(pr resolver.Identity, drafts []Draft) error {
+	path := s.File(pr)
+	if len(drafts) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove drafts: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(drafts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode drafts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create draft directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package draft
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+var testPR = resolver.Identity{Host: "github.com", Owner: "octo", Repo: "demo", Number: 7}
+
+func TestStoreAddAndList(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+
+	drafts, err := store.List(testPR)
+	require.NoError(t, err)
+	assert.Empty(t, drafts)
+
+	created := time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC)
+	_, err = store.Add(testPR, Draft{Path: "main.go", Line: 4, Side: "RIGHT", Body: "first", CreatedAt: created})
+	require.NoError(t, err)
+	_, err = store.Add(testPR, Draft{Path: "util.go", Line: 9, Side: "LEFT", Body: "second", CreatedAt: created})
+	require.NoError(t, err)
+
+	drafts, err = store.List(testPR)
+	require.NoError(t, err)
+	require.Len(t, drafts, 2)
+	assert.Equal(t, "first", drafts[0].Body)
+	assert.Equal(t, "second", drafts[1].Body)
+	assert.Equal(t, filepath.Join(store.Dir, "github.com", "octo", "demo", "7.json"), store.File(testPR))
+
+	other := testPR
+	other.Number = 8
+	drafts, err = store.List(other)
+	require.NoError(t, err)
+	assert.Empty(t, drafts, "drafts are kept per pull request")
+}
+
+func TestStoreAddValidates(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+
+	_, err := store.Add(testPR, Draft{Line: 1, Body: "x"})
+	assert.EqualError(t, err, "path is required")
+	_, err = store.Add(testPR, Draft{Path: "a.go", Body: "x"})
+	assert.EqualError(t, err, "line must be positive")
+	_, err = store.Add(testPR, Draft{Path: "a.go", Line: 1, Body: "  "})
+	assert.EqualError(t, err, "body is required")
+}
+
+func TestStoreFlushRemovesFileWhenAllPosted(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	_, err := store.Add(testPR, Draft{Path: "a.go", Line: 1, Body: "one"})
+	require.NoError(t, err)
+
+	var posted []string
+	results, err := store.Flush(testPR, func(d Draft) error {
+		posted = append(posted, d.Body)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, []string{"one"}, posted)
+
+	_, err = os.Stat(store.File(testPR))
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected draft file to be removed, got %v", err)
+}
+
+func TestStoreFlushKeepsFailedDrafts(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	for _, body := range []string{"one", "two", "three"} {
+		_, err := store.Add(testPR, Draft{Path: "a.go", Line: 1, Body: body})
+		require.NoError(t, err)
+	}
+
+	results, err := store.Flush(testPR, func(d Draft) error {
+		if d.Body == "two" {
+			return errors.New("line outside the diff")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "line outside the diff")
+	assert.NoError(t, results[2].Err)
+
+	remaining, err := store.List(testPR)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "two", remaining[0].Body)
+}
+
+func TestStoreFlushPersistsAfterEachPost(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	for _, body := range []string{"one", "two", "three"} {
+		_, err := store.Add(testPR, Draft{Path: "a.go", Line: 1, Body: body})
+		require.NoError(t, err)
+	}
+
+	_, err := store.Flush(testPR, func(d Draft) error {
+		if d.Body != "three" {
+			return nil
+		}
+		// Simulate the process dying while posting the last draft: the
+		// drafts already posted must be gone from the store.
+		stored, err := store.List(testPR)
+		require.NoError(t, err)
+		bodies := make([]string, 0, len(stored))
+		for _, s := range stored {
+			bodies = append(bodies, s.Body)
+		}
+		assert.Equal(t, []string{"three"}, bodies)
+		return errors.New("connection reset")
+	})
+	require.NoError(t, err)
+
+	remaining, err := store.List(testPR)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "three", remaining[0].Body)
+}
+
+func TestNewStoreHonorsEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+
+	store, err := NewStore("")
+	require.NoError(t, err)
+	assert.Equal(t, dir, store.Dir)
+
+	store, err = NewStore("/explicit")
+	require.NoError(t, err)
+	assert.Equal(t, "/explicit", store.Dir)
+}
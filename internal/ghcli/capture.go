@@ -0,0 +1,115 @@
+package ghcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var captureNameRE = regexp.MustCompile(`[^0-9A-Za-z._-]+`)
+
+// CaptureAPI writes the raw response of every call issued through the wrapped
+// API to files in Dir, for attaching to bug reports. Nothing is redacted:
+// captures contain whatever GitHub returned, including private code and
+// comment bodies.
+//
+// Each call produces "<timestamp>-<seq>-<operation>.stdout.json" with the
+// response payload and, when the call failed, a matching ".stderr.txt" with
+// gh's stderr (or the error message when gh wrote none).
+type CaptureAPI struct {
+	API API
+	Dir string
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewCaptureAPI wraps api so each response is written under dir.
+func NewCaptureAPI(api API, dir string) *CaptureAPI {
+	return &CaptureAPI{API: api, Dir: dir, Now: time.Now}
+}
+
+// REST forwards a REST call and captures its response.
+func (c *CaptureAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	var raw json.RawMessage
+	err := c.API.REST(method, path, params, body, &raw)
+	return c.finish(fmt.Sprintf("rest-%s-%s", method, path), raw, err, func() error {
+		if err := json.Unmarshal(raw, result); err != nil {
+			return fmt.Errorf("unmarshal response: %w", err)
+		}
+		return nil
+	}, result)
+}
+
+// GraphQL forwards a GraphQL call and captures the whole response body,
+// including any "errors" GitHub returned next to the data, before decoding it.
+func (c *CaptureAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	var raw RawGraphQLResponse
+	err := c.API.GraphQL(query, variables, &raw)
+	return c.finish("graphql-"+OperationName(query), json.RawMessage(raw), err, func() error {
+		return decodeGraphQLResponse(raw, result)
+	}, result)
+}
+
+func (c *CaptureAPI) finish(operation string, raw json.RawMessage, callErr error, decode func() error, result interface{}) error {
+	if writeErr := c.write(operation, raw, callErr); writeErr != nil {
+		return writeErr
+	}
+	if callErr != nil {
+		return callErr
+	}
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+	return decode()
+}
+
+func (c *CaptureAPI) write(operation string, raw json.RawMessage, callErr error) error {
+	c.mu.Lock()
+	c.seq++
+	seq := c.seq
+	c.mu.Unlock()
+
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	name := strings.Trim(captureNameRE.ReplaceAllString(operation, "-"), "-")
+	base := filepath.Join(c.Dir, fmt.Sprintf("%s-%03d-%s", now().UTC().Format("20060102T150405.000000000Z"), seq, name))
+
+	stdout := []byte(raw)
+	var stderr string
+	if callErr != nil {
+		stderr = callErr.Error()
+		var apiErr *APIError
+		if errors.As(callErr, &apiErr) {
+			if apiErr.Stderr != "" {
+				stderr = apiErr.Stderr
+			}
+			if len(stdout) == 0 && apiErr.Body != "" {
+				stdout = []byte(apiErr.Body)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return fmt.Errorf("create capture dir: %w", err)
+	}
+	if err := os.WriteFile(base+".stdout.json", stdout, 0o600); err != nil {
+		return fmt.Errorf("write capture: %w", err)
+	}
+	if stderr != "" {
+		if err := os.WriteFile(base+".stderr.txt", []byte(stderr), 0o600); err != nil {
+			return fmt.Errorf("write capture: %w", err)
+		}
+	}
+	return nil
+}
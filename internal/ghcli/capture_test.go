@@ -0,0 +1,91 @@
+package ghcli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cannedAPI struct {
+	rest    string
+	restErr error
+	graphql string
+}
+
+func (c *cannedAPI) REST(_ string, _ string, _ map[string]string, _ interface{}, result interface{}) error {
+	if c.restErr != nil {
+		return c.restErr
+	}
+	return json.Unmarshal([]byte(c.rest), result)
+}
+
+func (c *cannedAPI) GraphQL(_ string, _ map[string]interface{}, result interface{}) error {
+	if c.graphql == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(c.graphql), result)
+}
+
+func TestCaptureAPIWritesStdoutAndStderr(t *testing.T) {
+	dir := t.TempDir()
+	inner := &cannedAPI{rest: `{"full_name":"octo/demo"}`}
+	capture := NewCaptureAPI(inner, dir)
+	capture.Now = func() time.Time { return time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC) }
+
+	var repo struct {
+		FullName string `json:"full_name"`
+	}
+	require.NoError(t, capture.REST("GET", "repos/octo/demo", nil, nil, &repo))
+	assert.Equal(t, "octo/demo", repo.FullName)
+
+	inner.restErr = &APIError{StatusCode: 404, Message: "Not Found", Stderr: "gh: Not Found (HTTP 404)", Body: `{"message":"Not Found"}`}
+	err := capture.REST("GET", "repos/octo/missing", nil, nil, &repo)
+	require.ErrorIs(t, err, inner.restErr)
+
+	stamp := "20251203T100000.000000000Z"
+	first, err := os.ReadFile(filepath.Join(dir, stamp+"-001-rest-GET-repos-octo-demo.stdout.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"full_name":"octo/demo"}`, string(first))
+	assert.NoFileExists(t, filepath.Join(dir, stamp+"-001-rest-GET-repos-octo-demo.stderr.txt"))
+
+	body, err := os.ReadFile(filepath.Join(dir, stamp+"-002-rest-GET-repos-octo-missing.stdout.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"message":"Not Found"}`, string(body))
+	stderr, err := os.ReadFile(filepath.Join(dir, stamp+"-002-rest-GET-repos-octo-missing.stderr.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "gh: Not Found (HTTP 404)", string(stderr))
+}
+
+func TestCaptureAPIKeepsGraphQLErrors(t *testing.T) {
+	dir := t.TempDir()
+	inner := &cannedAPI{graphql: `{"data":{"viewer":{"login":"octo"}},"errors":[{"message":"Resource not accessible"}]}`}
+	capture := NewCaptureAPI(inner, dir)
+	capture.Now = func() time.Time { return time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC) }
+
+	var viewer struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	err := capture.GraphQL("query Viewer { viewer { login } }", nil, &viewer)
+	var gqlErr *GraphQLError
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Equal(t, "Resource not accessible", gqlErr.Errors[0].Message)
+
+	inner.graphql = `{"data":{"viewer":{"login":"octo"}}}`
+	require.NoError(t, capture.GraphQL("query Viewer { viewer { login } }", nil, &viewer))
+	assert.Equal(t, "octo", viewer.Viewer.Login)
+
+	stamp := "20251203T100000.000000000Z"
+	first, err := os.ReadFile(filepath.Join(dir, stamp+"-001-graphql-Viewer.stdout.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"viewer":{"login":"octo"}},"errors":[{"message":"Resource not accessible"}]}`, string(first))
+	second, err := os.ReadFile(filepath.Join(dir, stamp+"-002-graphql-Viewer.stdout.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"viewer":{"login":"octo"}}}`, string(second))
+}
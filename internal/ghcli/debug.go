@@ -0,0 +1,87 @@
+package ghcli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var operationNameRE = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// DebugAPI logs every request issued through the wrapped API with its timing.
+// Only the method, path or operation name, and parameter/variable keys are
+// logged; values are never written because they may carry secrets or bodies.
+type DebugAPI struct {
+	API API
+	Out io.Writer
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewDebugAPI wraps api so each call is logged to out.
+func NewDebugAPI(api API, out io.Writer) *DebugAPI {
+	return &DebugAPI{API: api, Out: out, Now: time.Now}
+}
+
+// REST logs and forwards a REST call.
+func (d *DebugAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	start := d.now()
+	err := d.API.REST(method, path, params, body, result)
+	d.log(fmt.Sprintf("REST %s %s", method, path), stringKeys(params), start, err)
+	return err
+}
+
+// GraphQL logs and forwards a GraphQL call.
+func (d *DebugAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	start := d.now()
+	err := d.API.GraphQL(query, variables, result)
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	d.log("GraphQL "+OperationName(query), keys, start, err)
+	return err
+}
+
+// OperationName returns the declared name of a GraphQL operation, or "anonymous".
+func OperationName(query string) string {
+	if m := operationNameRE.FindStringSubmatch(query); m != nil {
+		return m[2]
+	}
+	return "anonymous"
+}
+
+func (d *DebugAPI) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+func (d *DebugAPI) log(call string, keys []string, start time.Time, err error) {
+	var b strings.Builder
+	b.WriteString("debug: ")
+	b.WriteString(call)
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		b.WriteString(" [")
+		b.WriteString(strings.Join(keys, ", "))
+		b.WriteString("]")
+	}
+	fmt.Fprintf(&b, " %s", d.now().Sub(start).Round(time.Millisecond))
+	if err != nil {
+		b.WriteString(" error")
+	}
+	fmt.Fprintln(d.Out, b.String())
+}
+
+func stringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,50 @@
+package ghcli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAPI struct {
+	restErr error
+}
+
+func (r *recordingAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+	return r.restErr
+}
+
+func (r *recordingAPI) GraphQL(string, map[string]interface{}, interface{}) error {
+	return nil
+}
+
+func TestDebugAPILogsRESTAndGraphQL(t *testing.T) {
+	var out bytes.Buffer
+	clock := time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC)
+	debug := NewDebugAPI(&recordingAPI{restErr: errors.New("boom")}, &out)
+	debug.Now = func() time.Time {
+		clock = clock.Add(25 * time.Millisecond)
+		return clock
+	}
+
+	err := debug.REST("GET", "repos/octo/demo", map[string]string{"per_page": "100"}, nil, nil)
+	require.Error(t, err)
+	require.NoError(t, debug.GraphQL("query Report($owner: String!) { viewer { login } }", map[string]interface{}{"owner": "octo", "token": "secret"}, nil))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "debug: REST GET repos/octo/demo [per_page] 25ms error", lines[0])
+	assert.Equal(t, "debug: GraphQL Report [owner, token] 25ms", lines[1])
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "100")
+}
+
+func TestOperationName(t *testing.T) {
+	assert.Equal(t, "ResolveThread", OperationName("\nmutation ResolveThread($threadId: ID!) {}"))
+	assert.Equal(t, "anonymous", OperationName("{ viewer { login } }"))
+}
@@ -2,18 +2,48 @@ package ghcli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Client executes GitHub API requests through the `gh` CLI to reuse
 // the authenticated context and host configuration provided by the user.
 type Client struct {
 	Host string
+	// GhPath is the gh executable to run; empty falls back to $GH_PATH, then
+	// to "gh" on PATH.
+	GhPath string
+	// RequestTimeout bounds each individual gh invocation; zero disables it.
+	// It applies on top of any deadline carried by the request context.
+	RequestTimeout time.Duration
+}
+
+// GhPathEnv names the environment variable that overrides the gh executable.
+const GhPathEnv = "GH_PATH"
+
+// Executable resolves the gh binary to run: path when set, otherwise $GH_PATH,
+// otherwise "gh" looked up on PATH.
+func Executable(path string) (string, error) {
+	name := strings.TrimSpace(path)
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv(GhPathEnv))
+	}
+	if name == "" {
+		name = "gh"
+	}
+	resolved, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("gh executable not found at %s: %w", name, err)
+	}
+	return resolved, nil
 }
 
 // API defines the subset of GitHub API interactions required by the command logic.
@@ -22,6 +52,39 @@ type API interface {
 	GraphQL(query string, variables map[string]interface{}, result interface{}) error
 }
 
+// ContextAPI extends API with variants that bind each request to a context.
+type ContextAPI interface {
+	API
+	RESTContext(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error
+	GraphQLContext(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error
+}
+
+// WithContext returns an API whose calls honour ctx cancellation and deadlines.
+// Clients that do not implement ContextAPI are returned unchanged.
+func WithContext(ctx context.Context, api API) API {
+	if ctx == nil {
+		return api
+	}
+	contextual, ok := api.(ContextAPI)
+	if !ok {
+		return api
+	}
+	return &boundAPI{ctx: ctx, api: contextual}
+}
+
+type boundAPI struct {
+	ctx context.Context
+	api ContextAPI
+}
+
+func (b *boundAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	return b.api.RESTContext(b.ctx, method, path, params, body, result)
+}
+
+func (b *boundAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	return b.api.GraphQLContext(b.ctx, query, variables, result)
+}
+
 // GraphQLErrorEntry captures a single GraphQL error payload.
 type GraphQLErrorEntry struct {
 	Message string        `json:"message"`
@@ -48,11 +111,14 @@ func (e *GraphQLError) Error() string {
 }
 
 // APIError wraps errors returned by the `gh api` command, exposing the HTTP status code when detected.
+// Timeout is set when a single request exceeded its RequestTimeout, which
+// callers may treat as transient and retry.
 type APIError struct {
 	StatusCode int
 	Message    string
 	Stderr     string
 	Body       string
+	Timeout    bool
 	Err        error
 }
 
@@ -87,7 +153,15 @@ func (e *APIError) ContainsLower(target string) bool {
 
 var statusRE = regexp.MustCompile(`HTTP\s+(\d{3})\b`)
 
-func wrapError(err error, stdout []byte, stderr string) error {
+func wrapError(ctx context.Context, err error, stdout []byte, stderr string) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &APIError{Message: ctxErr.Error(), Stderr: stderr, Err: ctxErr}
+	}
+	var timeoutErr *APIError
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout {
+		return timeoutErr
+	}
+
 	message := strings.TrimSpace(stderr)
 	if message == "" {
 		message = err.Error()
@@ -111,18 +185,18 @@ func wrapError(err error, stdout []byte, stderr string) error {
 // REST invokes the REST API using `gh api`.
 // The result parameter must be a pointer and will be unmarshaled from JSON.
 func (c *Client) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
-	args := []string{"api"}
-	if host := strings.TrimSpace(c.Host); host != "" {
-		args = append(args, "--hostname", host)
-	}
-
-	args = append(args, "--header", "X-GitHub-Api-Version: 2022-11-28")
-	args = append(args, path, "-X", method)
+	return c.RESTContext(context.Background(), method, path, params, body, result)
+}
 
-	for key, value := range params {
-		args = append(args, "-f", fmt.Sprintf("%s=%s", key, value))
+// RESTContext behaves like REST but kills the `gh` subprocess when ctx is done.
+func (c *Client) RESTContext(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
+	executable, err := Executable(c.GhPath)
+	if err != nil {
+		return err
 	}
 
+	args := c.restArgs(method, path, params)
+
 	var stdinData []byte
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -133,9 +207,9 @@ func (c *Client) REST(method, path string, params map[string]string, body interf
 		args = append(args, "--input", "-")
 	}
 
-	stdout, stderr, err := runGh(args, stdinData)
+	stdout, stderr, err := runGh(ctx, executable, args, stdinData, c.RequestTimeout)
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return wrapError(ctx, err, stdout, stderr)
 	}
 
 	if result == nil {
@@ -149,8 +223,35 @@ func (c *Client) REST(method, path string, params map[string]string, body interf
 	return nil
 }
 
+// restArgs builds the `gh api` arguments for a REST call; params become -f
+// fields, which gh sends as query parameters for GET.
+func (c *Client) restArgs(method, path string, params map[string]string) []string {
+	args := []string{"api"}
+	if host := strings.TrimSpace(c.Host); host != "" {
+		args = append(args, "--hostname", host)
+	}
+
+	args = append(args, "--header", "X-GitHub-Api-Version: 2022-11-28")
+	args = append(args, path, "-X", method)
+
+	for key, value := range params {
+		args = append(args, "-f", fmt.Sprintf("%s=%s", key, value))
+	}
+	return args
+}
+
 // GraphQL issues a GraphQL operation through `gh api graphql`.
 func (c *Client) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	return c.GraphQLContext(context.Background(), query, variables, result)
+}
+
+// GraphQLContext behaves like GraphQL but kills the `gh` subprocess when ctx is done.
+func (c *Client) GraphQLContext(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	executable, err := Executable(c.GhPath)
+	if err != nil {
+		return err
+	}
+
 	payload := map[string]interface{}{
 		"query": query,
 	}
@@ -169,20 +270,41 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	}
 	args = append(args, "--input", "-")
 
-	stdout, stderr, err := runGh(args, data)
+	stdout, stderr, err := runGh(ctx, executable, args, data, c.RequestTimeout)
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return wrapError(ctx, err, stdout, stderr)
 	}
 
 	if result == nil {
 		return nil
 	}
+	return decodeGraphQLResponse(stdout, result)
+}
+
+// RawGraphQLResponse, passed as the result of a GraphQL call, receives the
+// response body as GitHub sent it, including any "errors", instead of the
+// decoded data.
+type RawGraphQLResponse []byte
+
+// UnmarshalJSON stores data unchanged, so API fakes that decode canned
+// payloads into the result fill a RawGraphQLResponse too.
+func (r *RawGraphQLResponse) UnmarshalJSON(data []byte) error {
+	*r = append((*r)[:0], data...)
+	return nil
+}
 
+// decodeGraphQLResponse unwraps the GraphQL envelope in body into result,
+// converting any reported errors into GraphQLError or APIError values.
+func decodeGraphQLResponse(body []byte, result interface{}) error {
+	if raw, ok := result.(*RawGraphQLResponse); ok {
+		*raw = append((*raw)[:0], body...)
+		return nil
+	}
 	var envelope struct {
 		Data   json.RawMessage   `json:"data"`
 		Errors []json.RawMessage `json:"errors"`
 	}
-	if err := json.Unmarshal(stdout, &envelope); err != nil {
+	if err := json.Unmarshal(body, &envelope); err != nil {
 		return fmt.Errorf("unmarshal graphql response: %w", err)
 	}
 	if len(envelope.Errors) > 0 {
@@ -194,7 +316,12 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 			}
 			errs = append(errs, entry)
 		}
-		return &GraphQLError{Errors: errs}
+		gqlErr := &GraphQLError{Errors: errs}
+		if isEmptyGraphQLData(envelope.Data) {
+			// HTTP 200 with no usable data: surface it like any other API failure.
+			return &APIError{Message: joinGraphQLMessages(errs), Body: strings.TrimSpace(string(body)), Err: gqlErr}
+		}
+		return gqlErr
 	}
 
 	if len(envelope.Data) > 0 && result != nil {
@@ -204,15 +331,48 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	}
 
 	if len(envelope.Data) == 0 && result != nil {
-		return json.Unmarshal(stdout, result)
+		return json.Unmarshal(body, result)
 	}
 
 	return nil
 }
 
-// runGh executes the `gh` CLI command with provided arguments and optional stdin data.
-func runGh(args []string, stdin []byte) ([]byte, string, error) {
-	cmd := exec.Command("gh", args...)
+func isEmptyGraphQLData(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) || bytes.Equal(trimmed, []byte("{}"))
+}
+
+func joinGraphQLMessages(errs []GraphQLErrorEntry) string {
+	parts := make([]string, 0, len(errs))
+	for _, entry := range errs {
+		if msg := strings.TrimSpace(entry.Message); msg != "" {
+			parts = append(parts, msg)
+		}
+	}
+	if len(parts) == 0 {
+		return "graphql returned errors"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// waitDelay bounds how long runGh waits for output pipes to close after the
+// subprocess is killed, so orphaned grandchildren cannot stall cancellation.
+const waitDelay = time.Second
+
+// runGh executes the gh executable with provided arguments and optional stdin data.
+// The subprocess is killed when ctx is cancelled or its deadline passes, or
+// after timeout when it is positive; the latter is reported as an APIError
+// with Timeout set.
+func runGh(ctx context.Context, executable string, args []string, stdin []byte, timeout time.Duration) ([]byte, string, error) {
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(runCtx, executable, args...)
+	cmd.WaitDelay = waitDelay
 	// DEBUG LOG
 	// fmt.Fprintf(os.Stderr, "running gh %s\n", strings.Join(args, " "))
 	if stdin != nil {
@@ -224,8 +384,15 @@ func runGh(args []string, stdin []byte) ([]byte, string, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
-	if err != nil {
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return stdout.Bytes(), stderr.String(), &APIError{
+				Message: fmt.Sprintf("request timed out after %s", timeout),
+				Stderr:  stderr.String(),
+				Timeout: true,
+				Err:     context.DeadlineExceeded,
+			}
+		}
 		return stdout.Bytes(), stderr.String(), err
 	}
 
@@ -0,0 +1,184 @@
+package ghcli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGh installs an executable `gh` shell script at the front of PATH.
+func stubGh(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs are not supported on windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestClientRESTContextDeadlineKillsSubprocess(t *testing.T) {
+	stubGh(t, "exec sleep 5")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	client := &Client{Host: "github.com"}
+	started := time.Now()
+	err := client.RESTContext(ctx, "GET", "user", nil, nil, &struct{}{})
+	elapsed := time.Since(started)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Less(t, elapsed, 3*time.Second)
+}
+
+func TestClientRequestTimeoutMarksAPIError(t *testing.T) {
+	stubGh(t, "exec sleep 5")
+
+	client := &Client{Host: "github.com", RequestTimeout: 100 * time.Millisecond}
+	started := time.Now()
+	err := client.GraphQL("query { viewer { login } }", nil, &struct{}{})
+	elapsed := time.Since(started)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, apiErr.Timeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+	assert.Contains(t, err.Error(), "request timed out after 100ms")
+	assert.Less(t, elapsed, 3*time.Second)
+}
+
+func TestClientCommandDeadlineIsNotRequestTimeout(t *testing.T) {
+	stubGh(t, "exec sleep 5")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	client := &Client{Host: "github.com", RequestTimeout: time.Minute}
+	err := client.RESTContext(ctx, "GET", "user", nil, nil, &struct{}{})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.False(t, apiErr.Timeout)
+}
+
+func TestClientHonorsGhPathEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs are not supported on windows")
+	}
+	// A broken gh on PATH proves the GH_PATH binary is the one that runs.
+	stubGh(t, "exit 1")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "custom-gh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho '{\"login\":\"octocat\"}'\n"), 0o755))
+	t.Setenv(GhPathEnv, path)
+
+	var result struct {
+		Login string `json:"login"`
+	}
+	require.NoError(t, (&Client{}).REST("GET", "user", nil, nil, &result))
+	assert.Equal(t, "octocat", result.Login)
+}
+
+func TestClientGhPathFieldOverridesEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs are not supported on windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "field-gh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho '{\"login\":\"field\"}'\n"), 0o755))
+	t.Setenv(GhPathEnv, filepath.Join(dir, "missing"))
+
+	var result struct {
+		Login string `json:"login"`
+	}
+	require.NoError(t, (&Client{GhPath: path}).REST("GET", "user", nil, nil, &result))
+	assert.Equal(t, "field", result.Login)
+}
+
+func TestClientReportsMissingGhExecutable(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "nope", "gh")
+	t.Setenv(GhPathEnv, missing)
+
+	err := (&Client{}).GraphQL("query { viewer { login } }", nil, &struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gh executable not found at "+missing)
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr), "expected a plain error, got %T", err)
+}
+
+func TestWithContextBindsGraphQL(t *testing.T) {
+	stubGh(t, "exec sleep 5")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	api := WithContext(ctx, &Client{})
+	err := api.GraphQL("query { viewer { login } }", nil, &struct{}{})
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+}
+
+func TestWithContextLeavesPlainAPIUntouched(t *testing.T) {
+	plain := &plainAPI{}
+	assert.Same(t, plain, WithContext(context.Background(), plain))
+}
+
+type plainAPI struct{}
+
+func (p *plainAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+	return nil
+}
+
+func (p *plainAPI) GraphQL(string, map[string]interface{}, interface{}) error {
+	return nil
+}
+
+func TestClientGraphQLSurfacesErrorsWithNullData(t *testing.T) {
+	stubGh(t, `cat >/dev/null
+printf '%s' '{"data":null,"errors":[{"message":"Could not resolve to a node with the global id of 'X'"},{"message":"Second failure"}]}'`)
+
+	var result struct {
+		Node *struct {
+			ID string `json:"id"`
+		} `json:"node"`
+	}
+	err := (&Client{}).GraphQL("query { node(id: \"X\") { id } }", nil, &result)
+
+	require.Error(t, err)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Contains(t, apiErr.Message, "Could not resolve to a node")
+	assert.Contains(t, apiErr.Message, "Second failure")
+	var gqlErr *GraphQLError
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Len(t, gqlErr.Errors, 2)
+	assert.Nil(t, result.Node)
+}
+
+func TestClientGraphQLKeepsPartialDataErrors(t *testing.T) {
+	stubGh(t, `cat >/dev/null
+printf '%s' '{"data":{"node":{"id":"X"}},"errors":[{"message":"partial"}]}'`)
+
+	var result struct{}
+	err := (&Client{}).GraphQL("query { node(id: \"X\") { id } }", nil, &result)
+
+	require.Error(t, err)
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr), "partial data should not be reported as an API error")
+	var gqlErr *GraphQLError
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Equal(t, "partial", gqlErr.Errors[0].Message)
+}
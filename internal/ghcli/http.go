@@ -0,0 +1,252 @@
+package ghcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// UseTokenEnv names the environment variable that forces HTTPClient even when
+// the gh CLI is installed.
+const UseTokenEnv = "GH_PR_REVIEW_USE_TOKEN"
+
+// HTTPClient talks to the GitHub REST and GraphQL APIs directly over HTTPS
+// with a token, for environments without the gh CLI. It implements the same
+// API and ContextAPI contracts as Client.
+type HTTPClient struct {
+	Host  string
+	Token string
+	// RESTURL and GraphQLURL override the endpoints derived from Host.
+	RESTURL    string
+	GraphQLURL string
+	// HTTP is the underlying client; nil uses http.DefaultClient.
+	HTTP *http.Client
+	// RequestTimeout bounds each individual request, like Client.RequestTimeout.
+	RequestTimeout time.Duration
+}
+
+// TokenFromEnv returns the token for host from GH_TOKEN or GITHUB_TOKEN, or,
+// for hosts other than github.com, only from GH_ENTERPRISE_TOKEN or
+// GITHUB_ENTERPRISE_TOKEN, as gh does: hosts come from pasted pull request
+// URLs, so the github.com token must never be sent elsewhere. It returns ""
+// when none is set.
+func TokenFromEnv(host string) string {
+	names := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if !isDotCom(host) {
+		names = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range names {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// NewHTTPClient builds an HTTPClient for host using the token from TokenFromEnv.
+func NewHTTPClient(host string) (*HTTPClient, error) {
+	token := TokenFromEnv(host)
+	if token == "" {
+		if !isDotCom(host) {
+			return nil, fmt.Errorf("no GitHub token found for %s (set GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN)", host)
+		}
+		return nil, errors.New("no GitHub token found (set GH_TOKEN or GITHUB_TOKEN)")
+	}
+	return &HTTPClient{Host: host, Token: token}, nil
+}
+
+func isDotCom(host string) bool {
+	host = strings.ToLower(strings.TrimSpace(host))
+	return host == "" || host == "github.com" || host == "api.github.com"
+}
+
+func (c *HTTPClient) endpoints() (string, string) {
+	restURL, graphqlURL := c.RESTURL, c.GraphQLURL
+	if isDotCom(c.Host) {
+		if restURL == "" {
+			restURL = "https://api.github.com"
+		}
+		if graphqlURL == "" {
+			graphqlURL = "https://api.github.com/graphql"
+		}
+	} else {
+		host := strings.TrimSpace(c.Host)
+		if restURL == "" {
+			restURL = "https://" + host + "/api/v3"
+		}
+		if graphqlURL == "" {
+			graphqlURL = "https://" + host + "/api/graphql"
+		}
+	}
+	return strings.TrimRight(restURL, "/"), graphqlURL
+}
+
+// REST issues a REST request. Like `gh api -f`, params are sent as query
+// parameters for GET and DELETE, and as JSON body fields otherwise unless an
+// explicit body is provided.
+func (c *HTTPClient) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	return c.RESTContext(context.Background(), method, path, params, body, result)
+}
+
+// RESTContext behaves like REST but aborts the request when ctx is done.
+func (c *HTTPClient) RESTContext(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	queryParams := method == http.MethodGet || method == http.MethodDelete || body != nil
+	target := c.restTarget(path, nil)
+	if queryParams {
+		target = c.restTarget(path, params)
+	}
+	if body == nil && len(params) > 0 && !queryParams {
+		body = params
+	}
+
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		payload = data
+	}
+
+	respBody, _, err := c.do(ctx, method, target, payload)
+	if err != nil {
+		return err
+	}
+	if result == nil || len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// GraphQL issues a GraphQL operation against the GraphQL endpoint.
+func (c *HTTPClient) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	return c.GraphQLContext(context.Background(), query, variables, result)
+}
+
+// GraphQLContext behaves like GraphQL but aborts the request when ctx is done.
+func (c *HTTPClient) GraphQLContext(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	payload := map[string]interface{}{
+		"query": query,
+	}
+	if len(variables) > 0 {
+		payload["variables"] = variables
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal graphql payload: %w", err)
+	}
+
+	_, graphqlURL := c.endpoints()
+	respBody, _, err := c.do(ctx, http.MethodPost, graphqlURL, data)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return decodeGraphQLResponse(respBody, result)
+}
+
+// restTarget returns the REST URL for path with params encoded as its query.
+func (c *HTTPClient) restTarget(path string, params map[string]string) string {
+	restURL, _ := c.endpoints()
+	target := restURL + "/" + strings.TrimLeft(path, "/")
+	if len(params) > 0 {
+		values := url.Values{}
+		for key, value := range params {
+			values.Set(key, value)
+		}
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+		target += separator + values.Encode()
+	}
+	return target
+}
+
+// do sends one request and returns the response body and headers, converting
+// non-2xx responses into APIError values carrying the status code.
+func (c *HTTPClient) do(ctx context.Context, method, target string, payload []byte) ([]byte, http.Header, error) {
+	reqCtx := ctx
+	if c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(reqCtx, method, target, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, &APIError{Message: ctxErr.Error(), Err: ctxErr}
+		}
+		if errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			return nil, nil, &APIError{
+				Message: fmt.Sprintf("request timed out after %s", c.RequestTimeout),
+				Timeout: true,
+				Err:     context.DeadlineExceeded,
+			}
+		}
+		return nil, nil, &APIError{Message: err.Error(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("read response: %v", err), Err: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		trimmed := strings.TrimSpace(string(body))
+		message := http.StatusText(resp.StatusCode)
+		var decoded struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &decoded) == nil && strings.TrimSpace(decoded.Message) != "" {
+			message = decoded.Message
+		}
+		return nil, nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    message,
+			Body:       trimmed,
+			Err:        fmt.Errorf("HTTP %d", resp.StatusCode),
+		}
+	}
+	return body, resp.Header, nil
+}
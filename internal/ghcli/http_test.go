@@ -0,0 +1,177 @@
+package ghcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHTTPClient(t *testing.T, handler http.HandlerFunc) *HTTPClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &HTTPClient{Host: "github.com", Token: "t0ken", RESTURL: server.URL, GraphQLURL: server.URL + "/graphql"}
+}
+
+func TestHTTPClientRESTSendsQueryParamsAndAuth(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/repos/octo/demo/pulls/7/comments", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "Bearer t0ken", r.Header.Get("Authorization"))
+		assert.Equal(t, "2022-11-28", r.Header.Get("X-GitHub-Api-Version"))
+		_, _ = w.Write([]byte(`[{"id":1}]`))
+	})
+
+	var result []struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, client.REST("GET", "repos/octo/demo/pulls/7/comments", map[string]string{"per_page": "100"}, nil, &result))
+	require.Len(t, result, 1)
+	assert.Equal(t, 1, result[0].ID)
+}
+
+func TestHTTPClientRESTSendsBody(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"body":"hi"}`, string(data))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":9}`))
+	})
+
+	var result struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, client.REST("POST", "repos/octo/demo/issues/7/comments", nil, map[string]string{"body": "hi"}, &result))
+	assert.Equal(t, 9, result.ID)
+}
+
+func TestHTTPClientRESTErrorCarriesStatus(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	err := client.REST("GET", "repos/octo/missing", nil, nil, &struct{}{})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Not Found", apiErr.Message)
+	assert.Equal(t, `{"message":"Not Found"}`, apiErr.Body)
+}
+
+func TestHTTPClientGraphQLUnwrapsData(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		var payload struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Contains(t, payload.Query, "viewer")
+		assert.Equal(t, "octo", payload.Variables["owner"])
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+	})
+
+	var result struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	require.NoError(t, client.GraphQL("query { viewer { login } }", map[string]interface{}{"owner": "octo"}, &result))
+	assert.Equal(t, "octocat", result.Viewer.Login)
+}
+
+func TestHTTPClientGraphQLErrors(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}},"errors":[{"message":"partial"}]}`))
+	})
+
+	err := client.GraphQL("query { viewer { login } }", nil, &struct{}{})
+	var gqlErr *GraphQLError
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Equal(t, "partial", gqlErr.Errors[0].Message)
+}
+
+func TestHTTPClientGraphQLRawResponseKeepsErrors(t *testing.T) {
+	body := `{"data":{"viewer":{"login":"octocat"}},"errors":[{"message":"partial"}]}`
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+
+	var raw RawGraphQLResponse
+	require.NoError(t, client.GraphQL("query { viewer { login } }", nil, &raw))
+	assert.Equal(t, body, string(raw))
+}
+
+func TestHTTPClientHonoursContextDeadline(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := WithContext(ctx, client).GraphQL("query { viewer { login } }", nil, &struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+}
+
+func TestHTTPClientRequestTimeoutMarksAPIError(t *testing.T) {
+	client := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	client.RequestTimeout = 50 * time.Millisecond
+
+	err := client.REST("GET", "user", nil, nil, &struct{}{})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, apiErr.Timeout)
+}
+
+func TestHTTPClientEndpoints(t *testing.T) {
+	restURL, graphqlURL := (&HTTPClient{Host: "github.com"}).endpoints()
+	assert.Equal(t, "https://api.github.com", restURL)
+	assert.Equal(t, "https://api.github.com/graphql", graphqlURL)
+
+	restURL, graphqlURL = (&HTTPClient{Host: "ghe.example.com"}).endpoints()
+	assert.Equal(t, "https://ghe.example.com/api/v3", restURL)
+	assert.Equal(t, "https://ghe.example.com/api/graphql", graphqlURL)
+}
+
+func TestTokenFromEnv(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "dotcom")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	assert.Equal(t, "dotcom", TokenFromEnv("github.com"))
+	assert.Equal(t, "enterprise", TokenFromEnv("ghe.example.com"))
+}
+
+func TestTokenFromEnvKeepsDotComTokenOffOtherHosts(t *testing.T) {
+	t.Setenv("GH_TOKEN", "dotcom")
+	t.Setenv("GITHUB_TOKEN", "dotcom")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	assert.Equal(t, "", TokenFromEnv("ghe.example.com"))
+	_, err := NewHTTPClient("ghe.example.com")
+	assert.EqualError(t, err, "no GitHub token found for ghe.example.com (set GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN)")
+}
@@ -0,0 +1,216 @@
+package ghcli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Paginator is implemented by clients that follow REST pagination through the
+// Link response header.
+type Paginator interface {
+	RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error
+}
+
+// ContextPaginator extends Paginator with a variant bound to a context.
+type ContextPaginator interface {
+	Paginator
+	RESTPaginateContext(ctx context.Context, method, path string, params map[string]string, perItem func(json.RawMessage) error) error
+}
+
+// RESTPaginate calls perItem for each element of the JSON array returned by
+// path and by every page linked from it with rel="next", stopping at the first
+// error. Clients that do not implement Paginator are paged by number instead,
+// starting at params["page"] and stopping at the first empty or short page.
+func RESTPaginate(api API, method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	if paginator, ok := api.(Paginator); ok {
+		return paginator.RESTPaginate(method, path, params, perItem)
+	}
+	return paginateByNumber(api, method, path, params, perItem)
+}
+
+// defaultPerPage is GitHub's page size when per_page is not given.
+const defaultPerPage = 30
+
+func paginateByNumber(api API, method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	perPage := defaultPerPage
+	if value, err := strconv.Atoi(params["per_page"]); err == nil && value > 0 {
+		perPage = value
+	}
+	page := 1
+	if value, err := strconv.Atoi(params["page"]); err == nil && value > 0 {
+		page = value
+	}
+
+	for ; ; page++ {
+		pageParams := make(map[string]string, len(params)+1)
+		for key, value := range params {
+			pageParams[key] = value
+		}
+		pageParams["page"] = strconv.Itoa(page)
+
+		var chunk []json.RawMessage
+		if err := api.REST(method, path, pageParams, nil, &chunk); err != nil {
+			return err
+		}
+		for _, item := range chunk {
+			if err := perItem(item); err != nil {
+				return err
+			}
+		}
+		if len(chunk) < perPage {
+			return nil
+		}
+	}
+}
+
+var linkNextRE = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// nextLink returns the rel="next" target of a Link header, or "".
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		if match := linkNextRE.FindStringSubmatch(part); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// eachItem decodes one page of a paginated response and hands its elements
+// to perItem.
+func eachItem(body []byte, perItem func(json.RawMessage) error) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return fmt.Errorf("unmarshal page: %w", err)
+	}
+	for _, item := range items {
+		if err := perItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitIncluded separates the status line and headers that `gh api --include`
+// prints ahead of the response body.
+func splitIncluded(output []byte) (http.Header, []byte, error) {
+	reader := bufio.NewReader(bytes.NewReader(output))
+	status, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(status, "HTTP/") {
+		return nil, nil, fmt.Errorf("unexpected response preamble %q", strings.TrimSpace(status))
+	}
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("read response headers: %w", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response body: %w", err)
+	}
+	return http.Header(header), body, nil
+}
+
+// RESTPaginate follows Link headers from `gh api --include`; see the
+// package-level RESTPaginate.
+func (c *Client) RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	return c.RESTPaginateContext(context.Background(), method, path, params, perItem)
+}
+
+// RESTPaginateContext behaves like RESTPaginate but kills the `gh` subprocess
+// when ctx is done. Pages after the first are requested by the absolute URL
+// from the Link header, which already carries the query parameters.
+func (c *Client) RESTPaginateContext(ctx context.Context, method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	executable, err := Executable(c.GhPath)
+	if err != nil {
+		return err
+	}
+
+	for target := path; target != ""; params = nil {
+		args := append(c.restArgs(method, target, params), "--include")
+		stdout, stderr, err := runGh(ctx, executable, args, nil, c.RequestTimeout)
+		if err != nil {
+			if _, body, splitErr := splitIncluded(stdout); splitErr == nil {
+				stdout = body
+			}
+			return wrapError(ctx, err, stdout, stderr)
+		}
+		header, body, err := splitIncluded(stdout)
+		if err != nil {
+			return err
+		}
+		if err := eachItem(body, perItem); err != nil {
+			return err
+		}
+		target = nextLink(header.Get("Link"))
+	}
+	return nil
+}
+
+// RESTPaginate follows Link headers; see the package-level RESTPaginate.
+func (c *HTTPClient) RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	return c.RESTPaginateContext(context.Background(), method, path, params, perItem)
+}
+
+// RESTPaginateContext behaves like RESTPaginate but aborts when ctx is done.
+// Params are sent as query parameters on the first request only.
+func (c *HTTPClient) RESTPaginateContext(ctx context.Context, method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	for target := c.restTarget(path, params); target != ""; {
+		body, header, err := c.do(ctx, method, target, nil)
+		if err != nil {
+			return err
+		}
+		if err := eachItem(body, perItem); err != nil {
+			return err
+		}
+		target = nextLink(header.Get("Link"))
+	}
+	return nil
+}
+
+func (b *boundAPI) RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	if paginator, ok := b.api.(ContextPaginator); ok {
+		return paginator.RESTPaginateContext(b.ctx, method, path, params, perItem)
+	}
+	return paginateByNumber(b, method, path, params, perItem)
+}
+
+// RESTPaginate logs and forwards a paginated REST call as a single entry.
+func (d *DebugAPI) RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	start := d.now()
+	err := RESTPaginate(d.API, method, path, params, perItem)
+	d.log(fmt.Sprintf("REST %s %s (paginated)", method, path), stringKeys(params), start, err)
+	return err
+}
+
+// RESTPaginate forwards a paginated REST call and captures every item it
+// returned as one JSON array.
+func (c *CaptureAPI) RESTPaginate(method, path string, params map[string]string, perItem func(json.RawMessage) error) error {
+	items := []json.RawMessage{}
+	err := RESTPaginate(c.API, method, path, params, func(item json.RawMessage) error {
+		items = append(items, item)
+		return perItem(item)
+	})
+	raw, marshalErr := json.Marshal(items)
+	if marshalErr != nil {
+		return fmt.Errorf("marshal capture: %w", marshalErr)
+	}
+	if writeErr := c.write(fmt.Sprintf("rest-%s-%s", method, path), raw, err); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package ghcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectIDs(t *testing.T, ids *[]int) func(json.RawMessage) error {
+	t.Helper()
+	return func(item json.RawMessage) error {
+		var decoded struct {
+			ID int `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(item, &decoded))
+		*ids = append(*ids, decoded.ID)
+		return nil
+	}
+}
+
+func TestClientRESTPaginateFollowsLinkHeader(t *testing.T) {
+	stubGh(t, `case "$*" in
+  *'&page=2'*)
+    printf 'HTTP/2.0 200 OK\r\nContent-Type: application/json\r\n\r\n[{"id":3}]' ;;
+  *--include*)
+    printf 'HTTP/2.0 200 OK\r\nLink: <https://api.github.com/repositories/1/pulls/7/reviews?per_page=100&page=2>; rel="next", <https://api.github.com/repositories/1/pulls/7/reviews?per_page=100&page=2>; rel="last"\r\n\r\n[{"id":1},{"id":2}]' ;;
+  *)
+    exit 1 ;;
+esac`)
+
+	var ids []int
+	err := (&Client{}).RESTPaginate("GET", "repos/octo/demo/pulls/7/reviews", map[string]string{"per_page": "100"}, collectIDs(t, &ids))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestHTTPClientRESTPaginateFollowsLinkHeader(t *testing.T) {
+	var requests int
+	var client *HTTPClient
+	client = newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/repos/octo/demo/pulls/7/reviews", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"id":3}]`))
+			return
+		}
+		// A short first page is not the last one while Link names a next page.
+		w.Header().Set("Link", `<`+client.RESTURL+`/repos/octo/demo/pulls/7/reviews?per_page=100&page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+	})
+
+	var ids []int
+	err := client.RESTPaginate("GET", "repos/octo/demo/pulls/7/reviews", map[string]string{"per_page": "100"}, collectIDs(t, &ids))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, 2, requests)
+}
+
+type numberedPagesAPI struct {
+	plainAPI
+	pages [][]int
+	seen  []string
+}
+
+func (n *numberedPagesAPI) REST(_ string, _ string, params map[string]string, _ interface{}, result interface{}) error {
+	n.seen = append(n.seen, params["page"])
+	page, _ := strconv.Atoi(params["page"])
+	items := []map[string]int{}
+	if page >= 1 && page <= len(n.pages) {
+		for _, id := range n.pages[page-1] {
+			items = append(items, map[string]int{"id": id})
+		}
+	}
+	data, _ := json.Marshal(items)
+	return json.Unmarshal(data, result)
+}
+
+func TestRESTPaginateFallsBackToPageNumbers(t *testing.T) {
+	api := &numberedPagesAPI{pages: [][]int{{1, 2}, {3, 4}, {5}}}
+
+	var ids []int
+	err := RESTPaginate(api, "GET", "repos/octo/demo/pulls/7/reviews", map[string]string{"per_page": "2"}, collectIDs(t, &ids))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, api.seen)
+}
+
+func TestNextLink(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/x?page=3", nextLink(`<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=3>; rel="next"`))
+	assert.Equal(t, "", nextLink(`<https://api.github.com/x?page=1>; rel="first"`))
+	assert.Equal(t, "", nextLink(""))
+}
@@ -0,0 +1,67 @@
+// Package pathglob matches slash-separated file paths against glob patterns.
+package pathglob
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether name matches pattern. Segments follow path.Match
+// semantics; a segment consisting solely of "**" matches zero or more
+// directories, so "internal/**/*.go" matches both "internal/a.go" and
+// "internal/a/b/c.go".
+func Match(pattern, name string) (bool, error) {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// MatchAny reports whether name matches at least one pattern.
+func MatchAny(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		ok, err := Match(pattern, name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Validate returns path.ErrBadPattern when any segment of pattern is malformed.
+func Validate(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(rest, name[i:])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false, err
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0, nil
+}
@@ -0,0 +1,47 @@
+package pathglob
+
+import (
+	"errors"
+	"path"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "main.go", name: "main.go", want: true},
+		{pattern: "*.go", name: "main.go", want: true},
+		{pattern: "*.go", name: "cmd/main.go", want: false},
+		{pattern: "cmd/*.go", name: "cmd/main.go", want: true},
+		{pattern: "internal/**/*.go", name: "internal/a.go", want: true},
+		{pattern: "internal/**/*.go", name: "internal/a/b/c.go", want: true},
+		{pattern: "internal/**/*.go", name: "cmd/a.go", want: false},
+		{pattern: "internal/**/*.go", name: "internal/a/b/c.md", want: false},
+		{pattern: "**/service.go", name: "service.go", want: true},
+		{pattern: "**/service.go", name: "internal/report/service.go", want: true},
+		{pattern: "docs/**", name: "docs/USAGE.md", want: true},
+		{pattern: "docs/**", name: "docs", want: true},
+	}
+
+	for _, tc := range cases {
+		got, err := Match(tc.pattern, tc.name)
+		if err != nil {
+			t.Fatalf("Match(%q, %q) error: %v", tc.pattern, tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Match(%q, %q) = %v, want %v", tc.pattern, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestValidateRejectsMalformedPattern(t *testing.T) {
+	if err := Validate("internal/**/[a-.go"); !errors.Is(err, path.ErrBadPattern) {
+		t.Fatalf("expected ErrBadPattern, got %v", err)
+	}
+	if err := Validate("internal/**/*.go"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
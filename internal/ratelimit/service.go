@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"errors"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+const rateLimitQuery = `query RateLimit {
+  rateLimit {
+    limit
+    used
+    remaining
+    resetAt
+    cost
+  }
+}`
+
+// Service reports the authenticated user's remaining API quota.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs a Service using the provided API client.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Quota describes one rate limit bucket.
+type Quota struct {
+	Limit     int    `json:"limit"`
+	Used      int    `json:"used"`
+	Remaining int    `json:"remaining"`
+	ResetAt   string `json:"reset_at"`
+}
+
+// GraphQLQuota is the GraphQL bucket, with the point cost of the query that
+// read it.
+type GraphQLQuota struct {
+	Quota
+	Cost int `json:"cost"`
+}
+
+// Status is the quota of every bucket the tool draws from.
+type Status struct {
+	REST    Quota        `json:"rest"`
+	GraphQL GraphQLQuota `json:"graphql"`
+	Search  Quota        `json:"search"`
+}
+
+type restBucket struct {
+	Limit     int   `json:"limit"`
+	Used      int   `json:"used"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+func (b restBucket) quota() Quota {
+	return Quota{
+		Limit:     b.Limit,
+		Used:      b.Used,
+		Remaining: b.Remaining,
+		ResetAt:   time.Unix(b.Reset, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+// Status reads the REST and search buckets from the REST rate_limit endpoint,
+// which does not count against the quota, and the GraphQL bucket from the
+// GraphQL rateLimit field.
+func (s *Service) Status() (*Status, error) {
+	var rest struct {
+		Resources struct {
+			Core   *restBucket `json:"core"`
+			Search *restBucket `json:"search"`
+		} `json:"resources"`
+	}
+	if err := s.API.REST("GET", "rate_limit", nil, nil, &rest); err != nil {
+		return nil, err
+	}
+	if rest.Resources.Core == nil || rest.Resources.Search == nil {
+		return nil, errors.New("rate_limit response missing core or search resources")
+	}
+
+	var graphql struct {
+		RateLimit *struct {
+			Limit     int    `json:"limit"`
+			Used      int    `json:"used"`
+			Remaining int    `json:"remaining"`
+			ResetAt   string `json:"resetAt"`
+			Cost      int    `json:"cost"`
+		} `json:"rateLimit"`
+	}
+	if err := s.API.GraphQL(rateLimitQuery, nil, &graphql); err != nil {
+		return nil, err
+	}
+	limit := graphql.RateLimit
+	if limit == nil {
+		return nil, errors.New("graphql response missing rateLimit")
+	}
+
+	return &Status{
+		REST: rest.Resources.Core.quota(),
+		GraphQL: GraphQLQuota{
+			Quota: Quota{
+				Limit:     limit.Limit,
+				Used:      limit.Used,
+				Remaining: limit.Remaining,
+				ResetAt:   limit.ResetAt,
+			},
+			Cost: limit.Cost,
+		},
+		Search: rest.Resources.Search.quota(),
+	}, nil
+}
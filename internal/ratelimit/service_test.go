@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPI struct {
+	restFunc    func(method, path string, params map[string]string, body interface{}, result interface{}) error
+	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
+}
+
+func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	if f.restFunc == nil {
+		return errors.New("unexpected REST call")
+	}
+	return f.restFunc(method, path, params, body, result)
+}
+
+func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	if f.graphqlFunc == nil {
+		return errors.New("unexpected GraphQL call")
+	}
+	return f.graphqlFunc(query, variables, result)
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestServiceStatusCombinesRESTAndGraphQL(t *testing.T) {
+	api := &fakeAPI{}
+	api.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "GET", method)
+		require.Equal(t, "rate_limit", path)
+		return assign(result, map[string]interface{}{"resources": map[string]interface{}{
+			"core":    map[string]interface{}{"limit": 5000, "used": 120, "remaining": 4880, "reset": 1764756000},
+			"search":  map[string]interface{}{"limit": 30, "used": 2, "remaining": 28, "reset": 1764752460},
+			"graphql": map[string]interface{}{"limit": 5000, "used": 1, "remaining": 4999, "reset": 1764756000},
+		}})
+	}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Equal(t, rateLimitQuery, query)
+		return assign(result, map[string]interface{}{"rateLimit": map[string]interface{}{
+			"limit": 5000, "used": 310, "remaining": 4690, "resetAt": "2025-12-03T10:30:00Z", "cost": 1,
+		}})
+	}
+
+	status, err := NewService(api).Status()
+	require.NoError(t, err)
+	assert.Equal(t, &Status{
+		REST:    Quota{Limit: 5000, Used: 120, Remaining: 4880, ResetAt: "2025-12-03T10:00:00Z"},
+		GraphQL: GraphQLQuota{Quota: Quota{Limit: 5000, Used: 310, Remaining: 4690, ResetAt: "2025-12-03T10:30:00Z"}, Cost: 1},
+		Search:  Quota{Limit: 30, Used: 2, Remaining: 28, ResetAt: "2025-12-03T09:01:00Z"},
+	}, status)
+}
+
+func TestServiceStatusRequiresGraphQLRateLimit(t *testing.T) {
+	api := &fakeAPI{}
+	api.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{"resources": map[string]interface{}{
+			"core":   map[string]interface{}{"limit": 5000},
+			"search": map[string]interface{}{"limit": 30},
+		}})
+	}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{"rateLimit": nil})
+	}
+
+	_, err := NewService(api).Status()
+	assert.EqualError(t, err, "graphql response missing rateLimit")
+}
@@ -4,25 +4,44 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/agynio/gh-pr-review/internal/pathglob"
 )
 
+// loginSet lowercases logins into a set, skipping blanks.
+func loginSet(logins []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(logins))
+	for _, login := range logins {
+		if login = strings.ToLower(strings.TrimSpace(login)); login != "" {
+			set[login] = struct{}{}
+		}
+	}
+	return set
+}
+
 // BuildReport aggregates reviews and threads into the serialized report format.
 func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Report {
-	allowedStates := allowedStateSet(filters.States)
+	allowedStates := allowedStateSet(filters.States, filters.ExcludeStates, filters.IncludeViewerPending)
 
-	var reviewerFilter string
-	if filters.Reviewer != "" {
-		reviewerFilter = strings.ToLower(filters.Reviewer)
-	}
+	reviewerFilter := loginSet(filters.Reviewers)
+	excluded := loginSet(filters.ExcludeReviewers)
+
+	resolvedBy := strings.ToLower(strings.TrimSpace(filters.ResolvedBy))
 
 	reportReviews := make([]ReportReview, 0, len(reviews))
 	reviewIndexByID := make(map[int]int, len(reviews))
+	submittedTimes := make([]*time.Time, 0, len(reviews))
 
 	for _, review := range reviews {
 		if _, ok := allowedStates[review.State]; !ok {
 			continue
 		}
-		if reviewerFilter != "" && strings.ToLower(review.AuthorLogin) != reviewerFilter {
+		if len(reviewerFilter) > 0 {
+			if _, ok := reviewerFilter[strings.ToLower(review.AuthorLogin)]; !ok {
+				continue
+			}
+		}
+		if _, ok := excluded[strings.ToLower(review.AuthorLogin)]; ok {
 			continue
 		}
 
@@ -46,14 +65,22 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			Body:        body,
 			SubmittedAt: submittedAt,
 			AuthorLogin: review.AuthorLogin,
+			Pending:     review.State == StatePending,
+		}
+		if filters.IncludeAuthorID {
+			rep.AuthorID = review.AuthorID
+		}
+		if review.State == StateDismissed {
+			rep.Dismissal = review.Dismissal
 		}
 
 		reviewIndexByID[review.DatabaseID] = len(reportReviews)
 		reportReviews = append(reportReviews, rep)
+		submittedTimes = append(submittedTimes, review.SubmittedAt)
 	}
 
 	if len(reportReviews) == 0 {
-		return Report{Reviews: []ReportReview{}}
+		return Report{SchemaVersion: SchemaVersion, Reviews: []ReportReview{}}
 	}
 
 	for _, thread := range threads {
@@ -63,6 +90,21 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		if filters.RequireNotOutdated && thread.IsOutdated {
 			continue
 		}
+		if filters.RequireOutdated && !thread.IsOutdated {
+			continue
+		}
+		if !matchesLocation(thread, filters) {
+			continue
+		}
+		if resolvedBy != "" && !resolvedByMatches(thread, resolvedBy) {
+			continue
+		}
+		if filters.RequireViewerAuthored && !viewerAuthored(thread) {
+			continue
+		}
+		if filters.RequireUnreplied && !awaitingViewer(thread) {
+			continue
+		}
 
 		var parent *ThreadComment
 		replies := make([]ThreadComment, 0, len(thread.Comments))
@@ -74,11 +116,23 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 				}
 				continue
 			}
+			if _, ok := excluded[strings.ToLower(comment.AuthorLogin)]; ok {
+				continue
+			}
 			replies = append(replies, comment)
 		}
 		if parent == nil || parent.ReviewDatabaseID == nil {
 			continue
 		}
+		if filters.NewSince != nil {
+			replies = createdSince(replies, *filters.NewSince)
+			if len(replies) == 0 && parent.CreatedAt.Before(*filters.NewSince) {
+				continue
+			}
+		}
+		if !meetsSeverity(parent.Body, filters.MinSeverity, filters.DropUnlabeled) {
+			continue
+		}
 
 		reviewIdx, ok := reviewIndexByID[*parent.ReviewDatabaseID]
 		if !ok {
@@ -89,10 +143,6 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			return replies[i].CreatedAt.Before(replies[j].CreatedAt)
 		})
 
-		if filters.TailReplies > 0 && len(replies) > filters.TailReplies {
-			replies = replies[len(replies)-filters.TailReplies:]
-		}
-
 		reportReplies := make([]ThreadReply, len(replies))
 		for i, reply := range replies {
 			createdAt := reply.CreatedAt.UTC().Format(time.RFC3339)
@@ -107,6 +157,19 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 				Body:          reply.Body,
 				CreatedAt:     createdAt,
 			}
+			if filters.IncludeAuthorID {
+				reportReplies[i].AuthorID = reply.AuthorID
+			}
+		}
+		if filters.DedupReplies {
+			reportReplies = dedupReplies(reportReplies)
+		}
+
+		if filters.TailReplies > 0 && len(reportReplies) > filters.TailReplies {
+			reportReplies = reportReplies[len(reportReplies)-filters.TailReplies:]
+		}
+		if filters.HeadReplies > 0 && len(reportReplies) > filters.HeadReplies {
+			reportReplies = reportReplies[:filters.HeadReplies]
 		}
 
 		createdAt := parent.CreatedAt.UTC().Format(time.RFC3339)
@@ -115,6 +178,16 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			id := parent.NodeID
 			commentNodeID = &id
 		}
+		var diffHunk *string
+		if filters.IncludeDiffHunk {
+			diffHunk = parent.DiffHunk
+		}
+		var context *string
+		if filters.ContextLines > 0 && parent.DiffHunk != nil {
+			if lines, ok := HunkContext(*parent.DiffHunk, thread.Line, thread.DiffSide, filters.ContextLines); ok {
+				context = &lines
+			}
+		}
 		reportComment := ReportComment{
 			ThreadID:       thread.ID,
 			CommentNodeID:  commentNodeID,
@@ -122,11 +195,19 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			Line:           thread.Line,
 			AuthorLogin:    parent.AuthorLogin,
 			Body:           parent.Body,
+			DiffHunk:       diffHunk,
+			Context:        context,
 			CreatedAt:      createdAt,
 			IsResolved:     thread.IsResolved,
 			IsOutdated:     thread.IsOutdated,
 			ThreadComments: reportReplies,
 		}
+		if filters.IncludeAuthorID {
+			reportComment.AuthorID = parent.AuthorID
+		}
+		if filters.SinceCommit != nil {
+			reportComment.OutdatedRelative = filters.SinceCommit.outdatedRelative(*parent, thread.IsOutdated)
+		}
 
 		if len(reportReplies) == 0 {
 			reportComment.ThreadComments = []ThreadReply{}
@@ -136,28 +217,194 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		review.Comments = append(review.Comments, reportComment)
 	}
 
-	for i := range reportReviews {
-		if len(reportReviews[i].Comments) == 0 {
-			reportReviews[i].Comments = nil
+	locationFiltered := len(filters.Paths) > 0 || filters.LineRange != nil
+	kept := reportReviews[:0]
+	for i, review := range reportReviews {
+		if len(review.Comments) == 0 {
+			// Location filters target comments; a review with neither matching
+			// comments nor a body has nothing left to show.
+			if locationFiltered && review.Body == nil {
+				continue
+			}
+			// Likewise, a review submitted before --new-since with no new
+			// comments is not part of the delta.
+			if filters.NewSince != nil && submittedTimes[i] != nil && submittedTimes[i].Before(*filters.NewSince) {
+				continue
+			}
+			review.Comments = nil
+		} else {
+			sortComments(review.Comments, filters.Order)
 		}
+		kept = append(kept, review)
+	}
+	if filters.CollapseResolved {
+		collapseResolved(kept, threads)
 	}
 
-	return Report{Reviews: reportReviews}
+	return Report{SchemaVersion: SchemaVersion, Reviews: kept}
 }
 
-func allowedStateSet(states []State) map[State]struct{} {
+// collapseResolved replaces each resolved thread's comment with a stub. It
+// runs after filtering and sorting, so stubs keep the comments' order, and
+// comment_count covers every comment in the thread, not just the replies
+// left after --tail or --head-replies or the first page GitHub returned.
+func collapseResolved(reviews []ReportReview, threads []Thread) {
+	counts := make(map[string]int, len(threads))
+	for _, thread := range threads {
+		counts[thread.ID] = max(thread.TotalComments, len(thread.Comments))
+	}
+	for i := range reviews {
+		review := &reviews[i]
+		open := review.Comments[:0]
+		for _, comment := range review.Comments {
+			if !comment.IsResolved {
+				open = append(open, comment)
+				continue
+			}
+			review.CollapsedThreads = append(review.CollapsedThreads, CollapsedThread{
+				ThreadID:     comment.ThreadID,
+				Path:         comment.Path,
+				Line:         comment.Line,
+				Resolved:     true,
+				CommentCount: counts[comment.ThreadID],
+			})
+		}
+		if len(open) == 0 {
+			open = nil
+		}
+		review.Comments = open
+	}
+}
+
+// createdSince keeps the comments created at or after since.
+func createdSince(comments []ThreadComment, since time.Time) []ThreadComment {
+	kept := comments[:0]
+	for _, comment := range comments {
+		if !comment.CreatedAt.Before(since) {
+			kept = append(kept, comment)
+		}
+	}
+	return kept
+}
+
+// resolvedByMatches reports whether a resolved thread was resolved by login (already lowercased).
+func resolvedByMatches(thread Thread, login string) bool {
+	return thread.IsResolved && thread.ResolvedBy != nil && strings.ToLower(*thread.ResolvedBy) == login
+}
+
+// viewerAuthored reports whether the authenticated user wrote any comment in the thread.
+func viewerAuthored(thread Thread) bool {
+	for _, comment := range thread.Comments {
+		if comment.ViewerDidAuthor {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupReplies collapses runs of consecutive replies with identical bodies
+// into the first of each run, recording the run length in Repeated.
+func dedupReplies(replies []ThreadReply) []ThreadReply {
+	kept := replies[:0]
+	for _, reply := range replies {
+		if n := len(kept); n > 0 && kept[n-1].Body == reply.Body {
+			if kept[n-1].Repeated == 0 {
+				kept[n-1].Repeated = 1
+			}
+			kept[n-1].Repeated++
+			continue
+		}
+		kept = append(kept, reply)
+	}
+	return kept
+}
+
+// awaitingViewer reports whether an unresolved thread's latest comment was
+// written by someone other than the authenticated user, leaving them to reply.
+func awaitingViewer(thread Thread) bool {
+	if thread.IsResolved || len(thread.Comments) == 0 {
+		return false
+	}
+	if thread.ViewerAuthoredLatest != nil {
+		return !*thread.ViewerAuthoredLatest
+	}
+	latest := thread.Comments[0]
+	for _, comment := range thread.Comments[1:] {
+		if !comment.CreatedAt.Before(latest.CreatedAt) {
+			latest = comment
+		}
+	}
+	return !latest.ViewerDidAuthor
+}
+
+// matchesLocation applies the path glob and line range filters to a thread.
+func matchesLocation(thread Thread, filters FilterOptions) bool {
+	if len(filters.Paths) > 0 {
+		// Patterns are validated by callers; malformed globs simply never match.
+		matched, err := pathglob.MatchAny(filters.Paths, thread.Path)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	if filters.LineRange != nil && !filters.LineRange.Contains(thread.Line) {
+		return false
+	}
+	return true
+}
+
+// sortComments orders parent comments in place; ties keep thread iteration order.
+func sortComments(comments []ReportComment, order CommentOrder) {
+	switch order {
+	case OrderPath:
+		sort.SliceStable(comments, func(i, j int) bool {
+			left, right := comments[i], comments[j]
+			if left.Path != right.Path {
+				return left.Path < right.Path
+			}
+			switch {
+			case left.Line == nil && right.Line == nil:
+			case left.Line == nil:
+				return false
+			case right.Line == nil:
+				return true
+			case *left.Line != *right.Line:
+				return *left.Line < *right.Line
+			}
+			return left.CreatedAt < right.CreatedAt
+		})
+	default:
+		// created_at values are normalized RFC3339 UTC strings, so lexical order is chronological.
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].CreatedAt < comments[j].CreatedAt
+		})
+	}
+}
+
+// allowedStateSet returns the requested states minus the excluded ones,
+// defaulting to submitted reviews only; pending reviews are included only when
+// explicitly requested or includePending is set.
+func allowedStateSet(states, exclude []State, includePending bool) map[State]struct{} {
+	var set map[State]struct{}
 	if len(states) == 0 {
-		return map[State]struct{}{
+		set = map[State]struct{}{
 			StateApproved:         {},
 			StateChangesRequested: {},
 			StateCommented:        {},
 			StateDismissed:        {},
 		}
+	} else {
+		set = make(map[State]struct{}, len(states))
+		for _, st := range states {
+			set[st] = struct{}{}
+		}
+	}
+
+	if includePending {
+		set[StatePending] = struct{}{}
 	}
 
-	set := make(map[State]struct{}, len(states))
-	for _, st := range states {
-		set[st] = struct{}{}
+	for _, st := range exclude {
+		delete(set, st)
 	}
 	return set
 }
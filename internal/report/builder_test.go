@@ -2,6 +2,7 @@ package report_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -163,6 +164,9 @@ func TestBuildReportAggregatesThreads(t *testing.T) {
 	if err != nil {
 		t.Fatalf("marshal report: %v", err)
 	}
+	if !strings.Contains(string(jsonBytes), `"schema_version":"`+report.SchemaVersion+`"`) {
+		t.Fatalf("expected schema_version %s encoded", report.SchemaVersion)
+	}
 	if !strings.Contains(string(jsonBytes), `"thread_comments":[]`) {
 		t.Fatal("expected empty thread_comments array encoded")
 	}
@@ -202,7 +206,7 @@ func TestBuildReportFilterOptions(t *testing.T) {
 	}
 
 	filters := report.FilterOptions{
-		Reviewer:           "bob",
+		Reviewers:          []string{"bob"},
 		States:             []report.State{report.StateChangesRequested},
 		RequireUnresolved:  true,
 		RequireNotOutdated: true,
@@ -239,6 +243,505 @@ func TestBuildReportFilterOptions(t *testing.T) {
 	}
 }
 
+func TestBuildReportOutdatedOnly(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	base := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+	thread := func(id string, outdated bool) report.Thread {
+		return report.Thread{ID: id, Path: "a.go", IsOutdated: outdated, Comments: []report.ThreadComment{
+			{NodeID: "C" + id, DatabaseID: 1, Body: "Parent", CreatedAt: base, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+		}}
+	}
+	threads := []report.Thread{thread("T1", true), thread("T2", false), thread("T3", true)}
+
+	threadIDs := func(filters report.FilterOptions) string {
+		var ids []string
+		for _, review := range report.BuildReport(reviews, threads, filters).Reviews {
+			for _, comment := range review.Comments {
+				ids = append(ids, comment.ThreadID)
+			}
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := threadIDs(report.FilterOptions{RequireOutdated: true}); got != "T1,T3" {
+		t.Fatalf("outdated-only threads = %s, want T1,T3", got)
+	}
+	if got := threadIDs(report.FilterOptions{RequireNotOutdated: true}); got != "T2" {
+		t.Fatalf("not-outdated threads = %s, want T2", got)
+	}
+	if got := threadIDs(report.FilterOptions{}); got != "T1,T2,T3" {
+		t.Fatalf("unfiltered threads = %s, want T1,T2,T3", got)
+	}
+}
+
+func TestBuildReportHeadVersusTailReplies(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	base := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+	comments := []report.ThreadComment{
+		{NodeID: "C1", DatabaseID: 1, Body: "Parent", CreatedAt: base, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+	}
+	// Replies are listed out of order to prove selection happens after sorting.
+	for i, minute := range []int{3, 1, 4, 2} {
+		comments = append(comments, report.ThreadComment{
+			NodeID:            "R" + string(rune('a'+i)),
+			DatabaseID:        10 + i,
+			Body:              "Reply" + string(rune('0'+minute)),
+			CreatedAt:         base.Add(time.Duration(minute) * time.Minute),
+			AuthorLogin:       "bob",
+			ReviewDatabaseID:  intPtr(1),
+			ReplyToDatabaseID: intPtr(1),
+		})
+	}
+	threads := []report.Thread{{ID: "T1", Path: "a.go", Comments: comments}}
+
+	bodies := func(filters report.FilterOptions) string {
+		replies := report.BuildReport(reviews, threads, filters).Reviews[0].Comments[0].ThreadComments
+		out := make([]string, len(replies))
+		for i, reply := range replies {
+			out[i] = reply.Body
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := bodies(report.FilterOptions{HeadReplies: 2}); got != "Reply1,Reply2" {
+		t.Fatalf("head replies = %s, want Reply1,Reply2", got)
+	}
+	if got := bodies(report.FilterOptions{TailReplies: 2}); got != "Reply3,Reply4" {
+		t.Fatalf("tail replies = %s, want Reply3,Reply4", got)
+	}
+	if got := bodies(report.FilterOptions{HeadReplies: 10}); got != "Reply1,Reply2,Reply3,Reply4" {
+		t.Fatalf("head larger than thread = %s, want all replies", got)
+	}
+}
+
+func TestBuildReportIncludesAuthorIDOnlyWhenRequested(t *testing.T) {
+	aliceID, bobID := int64(501), int64(502)
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", AuthorID: &aliceID, DatabaseID: 1}}
+	base := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+	threads := []report.Thread{{ID: "T1", Path: "a.go", Comments: []report.ThreadComment{
+		{NodeID: "C1", DatabaseID: 1, Body: "Parent", CreatedAt: base, AuthorLogin: "alice", AuthorID: &aliceID, ReviewDatabaseID: intPtr(1)},
+		{NodeID: "C2", DatabaseID: 2, Body: "Reply", CreatedAt: base.Add(time.Minute), AuthorLogin: "bob", AuthorID: &bobID, ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(1)},
+	}}}
+
+	plain := report.BuildReport(reviews, threads, report.FilterOptions{})
+	data, err := json.Marshal(plain)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	if strings.Contains(string(data), "author_id") {
+		t.Fatalf("expected author_id omitted by default, got %s", data)
+	}
+
+	withIDs := report.BuildReport(reviews, threads, report.FilterOptions{IncludeAuthorID: true})
+	review := withIDs.Reviews[0]
+	if review.AuthorID == nil || *review.AuthorID != aliceID {
+		t.Fatalf("expected review author_id %d, got %v", aliceID, review.AuthorID)
+	}
+	comment := review.Comments[0]
+	if comment.AuthorID == nil || *comment.AuthorID != aliceID {
+		t.Fatalf("expected comment author_id %d, got %v", aliceID, comment.AuthorID)
+	}
+	if reply := comment.ThreadComments[0]; reply.AuthorID == nil || *reply.AuthorID != bobID {
+		t.Fatalf("expected reply author_id %d, got %v", bobID, reply.AuthorID)
+	}
+}
+
+func TestBuildReportFiltersByReviewers(t *testing.T) {
+	reviews := []report.Review{
+		{ID: "R1", State: report.StateCommented, AuthorLogin: "Alice", DatabaseID: 1},
+		{ID: "R2", State: report.StateCommented, AuthorLogin: "bob", DatabaseID: 2},
+		{ID: "R3", State: report.StateApproved, AuthorLogin: "carol", DatabaseID: 3},
+	}
+
+	reviewIDs := func(r report.Report) string {
+		ids := make([]string, len(r.Reviews))
+		for i, review := range r.Reviews {
+			ids[i] = review.ID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := reviewIDs(report.BuildReport(reviews, nil, report.FilterOptions{Reviewers: []string{"alice"}})); got != "R1" {
+		t.Fatalf("expected single reviewer match, got %s", got)
+	}
+	if got := reviewIDs(report.BuildReport(reviews, nil, report.FilterOptions{Reviewers: []string{"ALICE", " carol "}})); got != "R1,R3" {
+		t.Fatalf("expected multiple reviewers matched case-insensitively, got %s", got)
+	}
+	if got := reviewIDs(report.BuildReport(reviews, nil, report.FilterOptions{Reviewers: []string{""}})); got != "R1,R2,R3" {
+		t.Fatalf("expected blank reviewer to disable filtering, got %s", got)
+	}
+}
+
+func TestBuildReportExcludeReviewers(t *testing.T) {
+	reviews := []report.Review{
+		{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1},
+		{ID: "R2", State: report.StateCommented, AuthorLogin: "lint-bot", DatabaseID: 2},
+		{ID: "R3", State: report.StateApproved, AuthorLogin: "carol", DatabaseID: 3},
+	}
+	threads := []report.Thread{{
+		ID:   "T1",
+		Path: "main.go",
+		Comments: []report.ThreadComment{
+			{NodeID: "C1", DatabaseID: 10, Body: "Rename this", AuthorLogin: "alice", CreatedAt: time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC), ReviewDatabaseID: intPtr(1)},
+			{NodeID: "C2", DatabaseID: 11, Body: "Automated note", AuthorLogin: "Lint-Bot", CreatedAt: time.Date(2025, 12, 3, 10, 1, 0, 0, time.UTC), ReviewDatabaseID: intPtr(2), ReplyToDatabaseID: intPtr(10)},
+			{NodeID: "C3", DatabaseID: 12, Body: "Agreed", AuthorLogin: "carol", CreatedAt: time.Date(2025, 12, 3, 10, 2, 0, 0, time.UTC), ReviewDatabaseID: intPtr(3), ReplyToDatabaseID: intPtr(10)},
+		},
+	}}
+
+	reviewIDs := func(r report.Report) string {
+		ids := make([]string, len(r.Reviews))
+		for i, review := range r.Reviews {
+			ids[i] = review.ID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	excludeOnly := report.BuildReport(reviews, threads, report.FilterOptions{ExcludeReviewers: []string{"LINT-BOT"}})
+	if got := reviewIDs(excludeOnly); got != "R1,R3" {
+		t.Fatalf("expected excluded reviewer dropped, got %s", got)
+	}
+	replies := excludeOnly.Reviews[0].Comments[0].ThreadComments
+	if len(replies) != 1 || replies[0].AuthorLogin != "carol" {
+		t.Fatalf("expected only carol's reply to remain, got %+v", replies)
+	}
+
+	combined := report.FilterOptions{Reviewers: []string{"alice", "lint-bot"}, ExcludeReviewers: []string{" lint-bot "}}
+	if got := reviewIDs(report.BuildReport(reviews, threads, combined)); got != "R1" {
+		t.Fatalf("expected exclusion to win over the include filter, got %s", got)
+	}
+}
+
+func TestBuildReportExcludeStates(t *testing.T) {
+	reviews := []report.Review{
+		{ID: "R1", State: report.StateApproved, AuthorLogin: "alice", DatabaseID: 1},
+		{ID: "R2", State: report.StateDismissed, AuthorLogin: "bob", DatabaseID: 2},
+		{ID: "R3", State: report.StateCommented, AuthorLogin: "carol", DatabaseID: 3},
+		{ID: "R4", State: report.StatePending, AuthorLogin: "dave", DatabaseID: 4},
+	}
+
+	reviewIDs := func(r report.Report) string {
+		ids := make([]string, len(r.Reviews))
+		for i, review := range r.Reviews {
+			ids[i] = review.ID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	excludeOnly := report.FilterOptions{ExcludeStates: []report.State{report.StateDismissed}}
+	if got := reviewIDs(report.BuildReport(reviews, nil, excludeOnly)); got != "R1,R3" {
+		t.Fatalf("expected exclusion from the default states, got %s", got)
+	}
+
+	combined := report.FilterOptions{
+		States:        []report.State{report.StateApproved, report.StateCommented, report.StatePending},
+		ExcludeStates: []report.State{report.StateCommented},
+	}
+	if got := reviewIDs(report.BuildReport(reviews, nil, combined)); got != "R1,R4" {
+		t.Fatalf("expected exclusion after the include filter, got %s", got)
+	}
+}
+
+func TestBuildReportNewSinceBoundaries(t *testing.T) {
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2025, 12, 3, hour, minute, second, 0, time.UTC)
+	}
+	since := at(11, 0, 0)
+	submitted := at(12, 0, 0)
+	old := at(8, 0, 0)
+	reviews := []report.Review{
+		{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1, SubmittedAt: &submitted},
+		{ID: "R2", State: report.StateApproved, AuthorLogin: "bob", DatabaseID: 2, SubmittedAt: &old, Body: strPtr("LGTM")},
+	}
+	threads := []report.Thread{
+		{ID: "T-reply-at-boundary", Path: "a.go", Comments: []report.ThreadComment{
+			{NodeID: "C1", DatabaseID: 1, Body: "Parent", CreatedAt: at(10, 0, 0), AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+			{NodeID: "C2", DatabaseID: 2, Body: "Old reply", CreatedAt: at(10, 59, 59), AuthorLogin: "bob", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(1)},
+			{NodeID: "C3", DatabaseID: 3, Body: "New reply", CreatedAt: since, AuthorLogin: "bob", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(1)},
+		}},
+		{ID: "T-old", Path: "b.go", Comments: []report.ThreadComment{
+			{NodeID: "C4", DatabaseID: 4, Body: "Just before", CreatedAt: at(10, 59, 59), AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+		}},
+		{ID: "T-parent-at-boundary", Path: "c.go", Comments: []report.ThreadComment{
+			{NodeID: "C5", DatabaseID: 5, Body: "Exactly at", CreatedAt: since, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+		}},
+	}
+
+	result := report.BuildReport(reviews, threads, report.FilterOptions{NewSince: &since})
+
+	if len(result.Reviews) != 1 || result.Reviews[0].ID != "R1" {
+		t.Fatalf("expected only review R1 to remain, got %+v", result.Reviews)
+	}
+	comments := result.Reviews[0].Comments
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 threads at or after the boundary, got %d", len(comments))
+	}
+	if comments[0].ThreadID != "T-reply-at-boundary" || comments[1].ThreadID != "T-parent-at-boundary" {
+		t.Fatalf("unexpected threads: %s, %s", comments[0].ThreadID, comments[1].ThreadID)
+	}
+	replies := comments[0].ThreadComments
+	if len(replies) != 1 || replies[0].Body != "New reply" {
+		t.Fatalf("expected only the reply at the boundary, got %+v", replies)
+	}
+	if len(comments[1].ThreadComments) != 0 {
+		t.Fatalf("expected no replies on the new thread, got %+v", comments[1].ThreadComments)
+	}
+}
+
+func TestBuildReportOrdersComments(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	threads := []report.Thread{
+		parentOnlyThread("T1", "zeta/handler.go", intPtr(5), 1, 1),
+		parentOnlyThread("T2", "alpha/main.go", intPtr(30), 4, 1),
+		parentOnlyThread("T3", "alpha/main.go", intPtr(3), 3, 1),
+		parentOnlyThread("T4", "alpha/main.go", nil, 0, 1),
+		parentOnlyThread("T5", "beta/util.go", intPtr(9), 2, 1),
+	}
+
+	chronological := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if got := threadIDs(chronological.Reviews[0].Comments); strings.Join(got, ",") != "T4,T1,T5,T3,T2" {
+		t.Fatalf("unexpected chronological order: %v", got)
+	}
+
+	byPath := report.BuildReport(reviews, threads, report.FilterOptions{Order: report.OrderPath})
+	if got := threadIDs(byPath.Reviews[0].Comments); strings.Join(got, ",") != "T3,T2,T4,T5,T1" {
+		t.Fatalf("unexpected path order: %v", got)
+	}
+}
+
+func TestBuildReportFiltersByPathAndLineRange(t *testing.T) {
+	body := "Overall fine"
+	reviews := []report.Review{
+		{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1},
+		{ID: "R2", State: report.StateCommented, AuthorLogin: "bob", Body: &body, DatabaseID: 2},
+	}
+	threads := []report.Thread{
+		parentOnlyThread("T1", "internal/report/builder.go", intPtr(9), 1, 1),
+		parentOnlyThread("T2", "internal/report/builder.go", intPtr(10), 2, 1),
+		parentOnlyThread("T3", "internal/report/builder.go", intPtr(20), 3, 1),
+		parentOnlyThread("T4", "internal/report/builder.go", intPtr(21), 4, 1),
+		parentOnlyThread("T5", "internal/report/builder.go", nil, 5, 1),
+		parentOnlyThread("T6", "cmd/root.go", intPtr(15), 6, 1),
+		parentOnlyThread("T7", "cmd/root.go", intPtr(15), 7, 2),
+	}
+
+	ranged := report.BuildReport(reviews, threads, report.FilterOptions{
+		Paths:     []string{"internal/**/*.go"},
+		LineRange: &report.LineRange{Start: 10, End: 20},
+	})
+	if len(ranged.Reviews) != 2 {
+		t.Fatalf("expected review with body kept despite no comments, got %d reviews", len(ranged.Reviews))
+	}
+	if got := strings.Join(threadIDs(ranged.Reviews[0].Comments), ","); got != "T2,T3" {
+		t.Fatalf("expected inclusive range boundaries, got %s", got)
+	}
+	if ranged.Reviews[1].ID != "R2" || ranged.Reviews[1].Comments != nil {
+		t.Fatalf("expected R2 kept without comments, got %+v", ranged.Reviews[1])
+	}
+
+	byPath := report.BuildReport(reviews, threads, report.FilterOptions{Paths: []string{"internal/*"}})
+	if len(byPath.Reviews) != 1 || byPath.Reviews[0].ID != "R2" {
+		t.Fatalf("expected only bodied review when no comments match, got %+v", byPath.Reviews)
+	}
+
+	single := report.BuildReport(reviews, threads, report.FilterOptions{LineRange: &report.LineRange{Start: 15, End: 15}})
+	if got := strings.Join(threadIDs(single.Reviews[0].Comments), ","); got != "T6" {
+		t.Fatalf("expected single-line range match, got %s", got)
+	}
+}
+
+func TestBuildReportRequireViewerAuthored(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	replied := parentOnlyThread("T-replied", "a.go", intPtr(1), 1, 1)
+	replied.Comments = append(replied.Comments, report.ThreadComment{
+		NodeID: "C_reply", DatabaseID: 9, Body: "Done", AuthorLogin: "me", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(0), ViewerDidAuthor: true,
+	})
+	started := parentOnlyThread("T-started", "b.go", intPtr(2), 2, 1)
+	started.Comments[0].ViewerDidAuthor = true
+	threads := []report.Thread{
+		replied,
+		parentOnlyThread("T-other", "c.go", intPtr(3), 3, 1),
+		started,
+	}
+
+	mine := report.BuildReport(reviews, threads, report.FilterOptions{RequireViewerAuthored: true})
+	if len(mine.Reviews) != 1 {
+		t.Fatalf("expected review R1 to remain, got %d reviews", len(mine.Reviews))
+	}
+	if got := strings.Join(threadIDs(mine.Reviews[0].Comments), ","); got != "T-replied,T-started" {
+		t.Fatalf("expected only threads the viewer commented in, got %s", got)
+	}
+
+	all := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if got := len(all.Reviews[0].Comments); got != 3 {
+		t.Fatalf("expected all threads without the filter, got %d", got)
+	}
+}
+
+func TestBuildReportRequireUnreplied(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	reply := func(thread *report.Thread, minute int, viewer bool) {
+		thread.Comments = append(thread.Comments, report.ThreadComment{
+			NodeID:            fmt.Sprintf("C_%s_%d", thread.ID, minute),
+			Body:              "Reply",
+			CreatedAt:         time.Date(2025, 12, 3, 10, minute, 0, 0, time.UTC),
+			AuthorLogin:       "someone",
+			ReviewDatabaseID:  intPtr(1),
+			ReplyToDatabaseID: intPtr(0),
+			ViewerDidAuthor:   viewer,
+		})
+	}
+	untouched := parentOnlyThread("T-untouched", "a.go", intPtr(1), 1, 1)
+	replied := parentOnlyThread("T-replied", "b.go", intPtr(2), 2, 1)
+	reply(&replied, 10, true)
+	followedUp := parentOnlyThread("T-followed-up", "c.go", intPtr(3), 3, 1)
+	reply(&followedUp, 10, true)
+	reply(&followedUp, 20, false)
+	resolved := parentOnlyThread("T-resolved", "d.go", intPtr(4), 4, 1)
+	resolved.IsResolved = true
+	started := parentOnlyThread("T-started", "e.go", intPtr(5), 5, 1)
+	started.Comments[0].ViewerDidAuthor = true
+	// T-long's first page of comments ends on the viewer's reply, but a later
+	// comment by someone else is newer.
+	long := parentOnlyThread("T-long", "f.go", intPtr(6), 6, 1)
+	reply(&long, 10, true)
+	long.ViewerAuthoredLatest = new(bool)
+	threads := []report.Thread{untouched, replied, followedUp, resolved, started, long}
+
+	unreplied := report.BuildReport(reviews, threads, report.FilterOptions{RequireUnreplied: true})
+	if len(unreplied.Reviews) != 1 {
+		t.Fatalf("expected review R1 to remain, got %d reviews", len(unreplied.Reviews))
+	}
+	if got := strings.Join(threadIDs(unreplied.Reviews[0].Comments), ","); got != "T-untouched,T-followed-up,T-long" {
+		t.Fatalf("expected only threads awaiting the viewer's reply, got %s", got)
+	}
+
+	all := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if got := len(all.Reviews[0].Comments); got != 6 {
+		t.Fatalf("expected all threads without the filter, got %d", got)
+	}
+}
+
+func TestBuildReportDedupReplies(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	withReplies := func(id string, line int, bodies ...string) report.Thread {
+		thread := parentOnlyThread(id, id+".go", intPtr(line), line, 1)
+		for i, body := range bodies {
+			thread.Comments = append(thread.Comments, report.ThreadComment{
+				NodeID:            fmt.Sprintf("C_%s_%d", id, i),
+				Body:              body,
+				CreatedAt:         time.Date(2025, 12, 3, 11, i, 0, 0, time.UTC),
+				AuthorLogin:       "ci-bot",
+				ReviewDatabaseID:  intPtr(1),
+				ReplyToDatabaseID: intPtr(0),
+			})
+		}
+		return thread
+	}
+	threads := []report.Thread{
+		withReplies("T-dup", 1, "Build passed", "Build passed", "Build passed", "Thanks", "Build passed"),
+		withReplies("T-distinct", 2, "One", "Two"),
+	}
+	repliesOf := func(r report.Report, i int) string {
+		parts := make([]string, 0)
+		for _, reply := range r.Reviews[0].Comments[i].ThreadComments {
+			parts = append(parts, fmt.Sprintf("%s/%d", reply.Body, reply.Repeated))
+		}
+		return strings.Join(parts, ",")
+	}
+
+	deduped := report.BuildReport(reviews, threads, report.FilterOptions{DedupReplies: true})
+	if got := repliesOf(deduped, 0); got != "Build passed/3,Thanks/0,Build passed/0" {
+		t.Fatalf("expected consecutive duplicates collapsed, got %s", got)
+	}
+	if got := repliesOf(deduped, 1); got != "One/0,Two/0" {
+		t.Fatalf("expected distinct replies untouched, got %s", got)
+	}
+	data, err := json.Marshal(deduped.Reviews[0].Comments[0].ThreadComments[:2])
+	if err != nil {
+		t.Fatalf("marshal replies: %v", err)
+	}
+	if !strings.Contains(string(data), `"repeated":3`) || strings.Count(string(data), "repeated") != 1 {
+		t.Fatalf("expected repeated only on the collapsed reply, got %s", data)
+	}
+
+	tail := report.BuildReport(reviews, threads, report.FilterOptions{DedupReplies: true, TailReplies: 2})
+	if got := repliesOf(tail, 0); got != "Thanks/0,Build passed/0" {
+		t.Fatalf("expected --tail to count collapsed replies once, got %s", got)
+	}
+
+	plain := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if got := len(plain.Reviews[0].Comments[0].ThreadComments); got != 5 {
+		t.Fatalf("expected duplicates kept by default, got %d replies", got)
+	}
+}
+
+func TestBuildReportCollapseResolved(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	resolved := parentOnlyThread("T-resolved", "a.go", intPtr(4), 1, 1)
+	resolved.IsResolved = true
+	for _, minute := range []int{5, 6} {
+		resolved.Comments = append(resolved.Comments, report.ThreadComment{
+			NodeID: "C_reply", DatabaseID: 10 + minute, Body: "Reply", AuthorLogin: "bob",
+			CreatedAt:        time.Date(2025, 12, 3, 10, minute, 0, 0, time.UTC),
+			ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(0),
+		})
+	}
+	threads := []report.Thread{resolved, parentOnlyThread("T-open", "b.go", intPtr(8), 2, 1)}
+
+	got := report.BuildReport(reviews, threads, report.FilterOptions{CollapseResolved: true, TailReplies: 1})
+	if len(got.Reviews) != 1 {
+		t.Fatalf("expected review R1, got %d reviews", len(got.Reviews))
+	}
+	review := got.Reviews[0]
+	if ids := strings.Join(threadIDs(review.Comments), ","); ids != "T-open" {
+		t.Fatalf("expected only the unresolved thread in full, got %s", ids)
+	}
+	if open := review.Comments[0]; open.Body != "Parent T-open" || open.ThreadComments == nil {
+		t.Fatalf("expected the unresolved thread unchanged, got %+v", open)
+	}
+	want := []report.CollapsedThread{{ThreadID: "T-resolved", Path: "a.go", Line: intPtr(4), Resolved: true, CommentCount: 3}}
+	gotJSON, _ := json.Marshal(review.CollapsedThreads)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("expected stub %s counting every thread comment, got %s", wantJSON, gotJSON)
+	}
+
+	threads[0].TotalComments = 150
+	paged := report.BuildReport(reviews, threads, report.FilterOptions{CollapseResolved: true})
+	if got := paged.Reviews[0].CollapsedThreads[0].CommentCount; got != 150 {
+		t.Fatalf("expected stub to count comments past the first page, got %d", got)
+	}
+
+	full := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if len(full.Reviews[0].Comments) != 2 || full.Reviews[0].CollapsedThreads != nil {
+		t.Fatalf("expected both threads in full without the flag, got %+v", full.Reviews[0])
+	}
+}
+
+// parentOnlyThread builds a thread with a single parent comment created minute minutes after a fixed base time.
+func parentOnlyThread(id, path string, line *int, minute int, reviewDatabaseID int) report.Thread {
+	return report.Thread{
+		ID:   id,
+		Path: path,
+		Line: line,
+		Comments: []report.ThreadComment{{
+			NodeID:           "C_" + id,
+			Body:             "Parent " + id,
+			CreatedAt:        time.Date(2025, 12, 3, 10, minute, 0, 0, time.UTC),
+			AuthorLogin:      "alice",
+			ReviewDatabaseID: intPtr(reviewDatabaseID),
+		}},
+	}
+}
+
+func threadIDs(comments []report.ReportComment) []string {
+	ids := make([]string, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ThreadID
+	}
+	return ids
+}
+
 func intPtr(v int) *int {
 	return &v
 }
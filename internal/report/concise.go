@@ -0,0 +1,38 @@
+package report
+
+// ConciseReport is the report reduced to one line of facts per review, for
+// quick scanning.
+type ConciseReport struct {
+	Reviews []ConciseReview `json:"reviews"`
+}
+
+// ConciseReview drops the review body and thread details, keeping how many
+// comments the full report lists under the review.
+type ConciseReview struct {
+	ID           string `json:"id"`
+	State        State  `json:"state"`
+	AuthorLogin  string `json:"author_login"`
+	CommentCount int    `json:"comment_count"`
+}
+
+// Concise reduces a shaped report to its reviews. Comment counts are taken
+// per review the same way Summarize totals them.
+func Concise(r Report) ConciseReport {
+	out := ConciseReport{Reviews: make([]ConciseReview, 0, len(r.Reviews))}
+	for _, review := range r.Reviews {
+		count := 0
+		for _, comment := range review.Comments {
+			count += threadCommentCount(comment)
+		}
+		for _, collapsed := range review.CollapsedThreads {
+			count += collapsed.CommentCount
+		}
+		out.Reviews = append(out.Reviews, ConciseReview{
+			ID:           review.ID,
+			State:        review.State,
+			AuthorLogin:  review.AuthorLogin,
+			CommentCount: count,
+		})
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestConciseOmitsBodiesAndReplies(t *testing.T) {
+	body := "Please address these"
+	input := report.Report{
+		SchemaVersion: report.SchemaVersion,
+		Reviews: []report.ReportReview{
+			{
+				ID:          "PRR_alice",
+				State:       report.StateChangesRequested,
+				Body:        &body,
+				AuthorLogin: "alice",
+				Comments: []report.ReportComment{
+					{
+						ThreadID:    "PRRT_one",
+						Path:        "main.go",
+						AuthorLogin: "alice",
+						Body:        "Handle the error",
+						ThreadComments: []report.ThreadReply{
+							{AuthorLogin: "bob", Body: "Agreed"},
+							{AuthorLogin: "ci-bot", Body: "Build passed", Repeated: 3},
+							{AuthorLogin: "alice", Body: "Thanks"},
+						},
+					},
+					{ThreadID: "PRRT_two", Path: "util.go", AuthorLogin: "alice", Body: "Rename", ThreadComments: []report.ThreadReply{}},
+				},
+				CollapsedThreads: []report.CollapsedThread{{ThreadID: "PRRT_done", Path: "old.go", Resolved: true, CommentCount: 2}},
+			},
+			{ID: "PRR_bob", State: report.StateApproved, AuthorLogin: "bob"},
+		},
+	}
+
+	data, err := json.Marshal(report.Concise(input))
+	if err != nil {
+		t.Fatalf("marshal concise report: %v", err)
+	}
+	want := `{"reviews":[` +
+		`{"id":"PRR_alice","state":"CHANGES_REQUESTED","author_login":"alice","comment_count":9},` +
+		`{"id":"PRR_bob","state":"APPROVED","author_login":"bob","comment_count":0}]}`
+	if string(data) != want {
+		t.Fatalf("unexpected concise report:\n got %s\nwant %s", data, want)
+	}
+	if total := report.Summarize(input).CommentsTotal; total != 9 {
+		t.Fatalf("expected concise counts to add up to the stats total, got %d", total)
+	}
+
+	empty, err := json.Marshal(report.Concise(report.Report{}))
+	if err != nil {
+		t.Fatalf("marshal concise report: %v", err)
+	}
+	if string(empty) != `{"reviews":[]}` {
+		t.Fatalf("unexpected concise report for empty input: %s", empty)
+	}
+}
@@ -10,16 +10,85 @@ const (
 	StateChangesRequested State = "CHANGES_REQUESTED"
 	StateCommented        State = "COMMENTED"
 	StateDismissed        State = "DISMISSED"
+	StatePending          State = "PENDING"
+)
+
+// CommentOrder controls how parent comments are ordered within each review.
+type CommentOrder string
+
+const (
+	// OrderChronological sorts parent comments by creation time (the default).
+	OrderChronological CommentOrder = "chronological"
+	// OrderPath sorts parent comments alphabetically by path, then by line.
+	OrderPath CommentOrder = "path"
 )
 
 // FilterOptions controls shaping of reviews and threads.
 type FilterOptions struct {
-	Reviewer             string
-	States               []State
-	RequireUnresolved    bool
-	RequireNotOutdated   bool
-	TailReplies          int
+	// Reviewers keeps reviews authored by any of the logins (case-insensitive).
+	Reviewers []string
+	// ExcludeReviewers drops reviews by these logins and their replies in the
+	// threads that remain (case-insensitive); it wins over Reviewers.
+	ExcludeReviewers []string
+	States           []State
+	// ExcludeStates drops reviews in these states after States is applied.
+	ExcludeStates      []State
+	RequireUnresolved  bool
+	RequireNotOutdated bool
+	// RequireOutdated keeps only outdated threads; exclusive with RequireNotOutdated.
+	RequireOutdated bool
+	TailReplies     int
+	// HeadReplies keeps only the first N replies per thread (0 = all); exclusive with TailReplies.
+	HeadReplies          int
 	IncludeCommentNodeID bool
+	IncludeDiffHunk      bool
+	// IncludeAuthorID adds author_id to reviews, comments, and replies whose author is a user.
+	IncludeAuthorID bool
+	// ContextLines attaches up to N diff lines around each parent comment's line (0 = off).
+	ContextLines int
+	Order        CommentOrder
+	// MinSeverity drops parent comments tagged below this severity (0 = no threshold).
+	MinSeverity Severity
+	// DropUnlabeled drops parent comments without a recognizable severity tag.
+	DropUnlabeled bool
+	// Paths keeps comments whose path matches any of the globs ("**" spans directories).
+	Paths []string
+	// LineRange keeps comments anchored within the inclusive line range.
+	LineRange *LineRange
+	// ResolvedBy keeps only resolved threads whose resolver matches the login (case-insensitive).
+	ResolvedBy string
+	// RequireViewerAuthored keeps only threads with a comment by the authenticated user.
+	RequireViewerAuthored bool
+	// RequireUnreplied keeps only unresolved threads whose latest comment was
+	// written by someone other than the authenticated user.
+	RequireUnreplied bool
+	// DedupReplies collapses consecutive replies with identical bodies within
+	// a thread into the first, counting them in Repeated. Applied before
+	// TailReplies and HeadReplies.
+	DedupReplies bool
+	// IncludeViewerPending adds the authenticated user's pending review to the
+	// requested states; GitHub only returns pending reviews to their author.
+	IncludeViewerPending bool
+	// NewSince drops replies created before the time and threads with no
+	// comment at or after it; parents stay as the anchor for new replies.
+	NewSince *time.Time
+	// CollapseResolved moves resolved threads out of review comments into
+	// CollapsedThreads stubs carrying the thread's total comment count.
+	CollapseResolved bool
+	// SinceCommit adds OutdatedRelative to parent comments, measured against
+	// the baseline commit instead of the pull request head.
+	SinceCommit *CommitBaseline
+}
+
+// LineRange is an inclusive range of file lines.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Contains reports whether line falls within the range; comments without a line never match.
+func (r LineRange) Contains(line *int) bool {
+	return line != nil && *line >= r.Start && *line <= r.End
 }
 
 // Review models a pull request review fetched from GraphQL.
@@ -29,7 +98,15 @@ type Review struct {
 	Body        *string
 	SubmittedAt *time.Time
 	AuthorLogin string
+	AuthorID    *int64
 	DatabaseID  int
+	Dismissal   *Dismissal
+}
+
+// Dismissal describes why and by whom a review was dismissed.
+type Dismissal struct {
+	Reason *string `json:"reason,omitempty"`
+	By     *string `json:"by,omitempty"`
 }
 
 // Thread captures a review thread and its constituent comments.
@@ -39,7 +116,16 @@ type Thread struct {
 	Line       *int
 	IsResolved bool
 	IsOutdated bool
+	ResolvedBy *string
 	Comments   []ThreadComment
+	// TotalComments is GitHub's count of the thread's comments and
+	// ViewerAuthoredLatest whether the user wrote the newest one; both cover
+	// comments past the first page, which Comments does not. Zero and nil
+	// mean unknown, and Comments is used instead.
+	TotalComments        int
+	ViewerAuthoredLatest *bool
+	// DiffSide is LEFT when Line numbers the old file and RIGHT otherwise.
+	DiffSide string
 }
 
 // ThreadComment represents a single comment node within a thread.
@@ -47,16 +133,50 @@ type ThreadComment struct {
 	NodeID             string
 	DatabaseID         int
 	Body               string
+	DiffHunk           *string
 	CreatedAt          time.Time
 	AuthorLogin        string
+	AuthorID           *int64
 	ReviewDatabaseID   *int
 	ReplyToDatabaseID  *int
 	ReplyToCommentNode *string
+	ViewerDidAuthor    bool
+	// CommitOID is the latest commit the comment still applies to and
+	// OriginalCommitOID the commit it was written against; either is empty
+	// when GitHub no longer exposes the commit.
+	CommitOID         string
+	OriginalCommitOID string
 }
 
+// SchemaVersion identifies the report output shape. Bump it only for
+// incompatible changes; additive fields keep the current version.
+const SchemaVersion = "1"
+
 // Report is the serialized output structure for the report command.
 type Report struct {
-	Reviews []ReportReview `json:"reviews"`
+	SchemaVersion string         `json:"schema_version,omitempty"`
+	Meta          *Meta          `json:"meta,omitempty"`
+	Reviews       []ReportReview `json:"reviews"`
+	Truncated     bool           `json:"truncated,omitempty"`
+	// Cost is set when Options.ReportCost was requested; it is never serialized.
+	Cost *QueryCost `json:"-"`
+}
+
+// QueryCost is the GraphQL rate limit cost of the queries behind a report.
+type QueryCost struct {
+	// Points is the total cost charged across all report queries.
+	Points int
+	// Remaining is the GraphQL quota left after the last query.
+	Remaining int
+	Queries   int
+}
+
+// Meta documents when, by which tool version, and for which pull request a report was generated.
+type Meta struct {
+	GeneratedAt string `json:"generated_at"`
+	ToolVersion string `json:"tool_version"`
+	PR          string `json:"pr"`
+	Host        string `json:"host"`
 }
 
 // ReportReview aggregates review data and associated thread comments.
@@ -64,29 +184,56 @@ type ReportReview struct {
 	ID          string          `json:"id"`
 	State       State           `json:"state"`
 	Body        *string         `json:"body,omitempty"`
+	Truncated   bool            `json:"truncated,omitempty"`
+	Pending     bool            `json:"pending,omitempty"`
 	SubmittedAt *string         `json:"submitted_at,omitempty"`
 	AuthorLogin string          `json:"author_login"`
+	AuthorID    *int64          `json:"author_id,omitempty"`
+	Dismissal   *Dismissal      `json:"dismissal,omitempty"`
 	Comments    []ReportComment `json:"comments,omitempty"`
+	// CollapsedThreads replaces resolved threads' comments when the report
+	// is built with CollapseResolved.
+	CollapsedThreads []CollapsedThread `json:"collapsed_threads,omitempty"`
+}
+
+// CollapsedThread is the compact stub left in place of a resolved thread's comment.
+type CollapsedThread struct {
+	ThreadID     string `json:"thread_id"`
+	Path         string `json:"path"`
+	Line         *int   `json:"line,omitempty"`
+	Resolved     bool   `json:"resolved"`
+	CommentCount int    `json:"comment_count"`
 }
 
 // ReportComment contains the shaped parent comment for a thread.
 type ReportComment struct {
-	ThreadID       string        `json:"thread_id"`
-	CommentNodeID  *string       `json:"comment_node_id,omitempty"`
-	Path           string        `json:"path"`
-	Line           *int          `json:"line,omitempty"`
-	AuthorLogin    string        `json:"author_login"`
-	Body           string        `json:"body"`
-	CreatedAt      string        `json:"created_at"`
-	IsResolved     bool          `json:"is_resolved"`
-	IsOutdated     bool          `json:"is_outdated"`
-	ThreadComments []ThreadReply `json:"thread_comments"`
+	ThreadID      string  `json:"thread_id"`
+	CommentNodeID *string `json:"comment_node_id,omitempty"`
+	Path          string  `json:"path"`
+	Line          *int    `json:"line,omitempty"`
+	AuthorLogin   string  `json:"author_login"`
+	AuthorID      *int64  `json:"author_id,omitempty"`
+	Body          string  `json:"body"`
+	Truncated     bool    `json:"truncated,omitempty"`
+	DiffHunk      *string `json:"diff_hunk,omitempty"`
+	Context       *string `json:"context,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+	IsResolved    bool    `json:"is_resolved"`
+	IsOutdated    bool    `json:"is_outdated"`
+	// OutdatedRelative is set with --since-commit when it can be determined.
+	OutdatedRelative *bool         `json:"outdated_relative,omitempty"`
+	ThreadComments   []ThreadReply `json:"thread_comments"`
 }
 
 // ThreadReply captures a reply within a thread.
 type ThreadReply struct {
 	CommentNodeID *string `json:"comment_node_id,omitempty"`
 	AuthorLogin   string  `json:"author_login"`
+	AuthorID      *int64  `json:"author_id,omitempty"`
 	Body          string  `json:"body"`
+	Truncated     bool    `json:"truncated,omitempty"`
 	CreatedAt     string  `json:"created_at"`
+	// Repeated counts the consecutive identical replies collapsed into this
+	// one by DedupReplies; it is omitted for replies that were not repeated.
+	Repeated int `json:"repeated,omitempty"`
 }
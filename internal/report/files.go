@@ -0,0 +1,66 @@
+package report
+
+import "sort"
+
+// FileStat rolls up the review activity on one file.
+type FileStat struct {
+	Path            string   `json:"path"`
+	CommentCount    int      `json:"comment_count"`
+	UnresolvedCount int      `json:"unresolved_count"`
+	Reviewers       []string `json:"reviewers"`
+}
+
+// FileSummary rolls a shaped report up by file path. Comment counts are taken
+// per file the same way Summarize totals them; unresolved counts threads.
+// Reviewers are the sorted logins of everyone who commented on the file.
+// Files are ordered by comment count, most discussed first, then by path.
+func FileSummary(r Report) []FileStat {
+	stats := make(map[string]*FileStat)
+	reviewers := make(map[string]map[string]struct{})
+	file := func(path string) *FileStat {
+		stat, ok := stats[path]
+		if !ok {
+			stat = &FileStat{Path: path, Reviewers: []string{}}
+			stats[path] = stat
+			reviewers[path] = make(map[string]struct{})
+		}
+		return stat
+	}
+	addReviewer := func(path, login string) {
+		if _, ok := reviewers[path][login]; ok || login == "" {
+			return
+		}
+		reviewers[path][login] = struct{}{}
+		stats[path].Reviewers = append(stats[path].Reviewers, login)
+	}
+
+	for _, review := range r.Reviews {
+		for _, comment := range review.Comments {
+			stat := file(comment.Path)
+			stat.CommentCount += threadCommentCount(comment)
+			if !comment.IsResolved {
+				stat.UnresolvedCount++
+			}
+			addReviewer(comment.Path, comment.AuthorLogin)
+			for _, reply := range comment.ThreadComments {
+				addReviewer(comment.Path, reply.AuthorLogin)
+			}
+		}
+		for _, collapsed := range review.CollapsedThreads {
+			file(collapsed.Path).CommentCount += collapsed.CommentCount
+		}
+	}
+
+	files := make([]FileStat, 0, len(stats))
+	for _, stat := range stats {
+		sort.Strings(stat.Reviewers)
+		files = append(files, *stat)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].CommentCount != files[j].CommentCount {
+			return files[i].CommentCount > files[j].CommentCount
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
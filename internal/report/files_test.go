@@ -0,0 +1,60 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestFileSummaryRollsUpByPath(t *testing.T) {
+	input := report.Report{
+		Reviews: []report.ReportReview{
+			{
+				ID:          "PRR_alice",
+				State:       report.StateChangesRequested,
+				AuthorLogin: "alice",
+				Comments: []report.ReportComment{
+					{
+						ThreadID:    "PRRT_1",
+						Path:        "main.go",
+						AuthorLogin: "alice",
+						ThreadComments: []report.ThreadReply{
+							{AuthorLogin: "carol"},
+							{AuthorLogin: "ci-bot", Repeated: 2},
+							{AuthorLogin: "alice"},
+						},
+					},
+					{ThreadID: "PRRT_2", Path: "util.go", AuthorLogin: "alice", IsResolved: true, ThreadComments: []report.ThreadReply{}},
+				},
+				CollapsedThreads: []report.CollapsedThread{{ThreadID: "PRRT_3", Path: "docs.md", Resolved: true, CommentCount: 1}},
+			},
+			{
+				ID:          "PRR_bob",
+				State:       report.StateCommented,
+				AuthorLogin: "bob",
+				Comments: []report.ReportComment{
+					{ThreadID: "PRRT_4", Path: "main.go", AuthorLogin: "bob", IsResolved: true, ThreadComments: []report.ThreadReply{}},
+					{ThreadID: "PRRT_5", Path: "api.go", AuthorLogin: "bob", ThreadComments: []report.ThreadReply{}},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(report.FileSummary(input))
+	if err != nil {
+		t.Fatalf("marshal file summary: %v", err)
+	}
+	want := `[` +
+		`{"path":"main.go","comment_count":6,"unresolved_count":1,"reviewers":["alice","bob","carol","ci-bot"]},` +
+		`{"path":"api.go","comment_count":1,"unresolved_count":1,"reviewers":["bob"]},` +
+		`{"path":"docs.md","comment_count":1,"unresolved_count":0,"reviewers":[]},` +
+		`{"path":"util.go","comment_count":1,"unresolved_count":0,"reviewers":["alice"]}]`
+	if string(data) != want {
+		t.Fatalf("unexpected file summary:\n got %s\nwant %s", data, want)
+	}
+
+	if files := report.FileSummary(report.Report{}); files == nil || len(files) != 0 {
+		t.Fatalf("expected an empty, non-nil summary for an empty report, got %#v", files)
+	}
+}
@@ -0,0 +1,64 @@
+package report
+
+import "sort"
+
+// ReviewerReport is the report regrouped by reviewer login.
+type ReviewerReport struct {
+	SchemaVersion string          `json:"schema_version,omitempty"`
+	Meta          *Meta           `json:"meta,omitempty"`
+	Reviewers     []ReviewerGroup `json:"reviewers"`
+	Truncated     bool            `json:"truncated,omitempty"`
+}
+
+// ReviewerGroup collects one reviewer's reviews and the threads they started.
+// Reviews carry no comments here; the reviewer's parent comments are listed
+// under Comments regardless of which review they were posted in.
+type ReviewerGroup struct {
+	Login    string          `json:"login"`
+	Reviews  []ReportReview  `json:"reviews"`
+	Comments []ReportComment `json:"comments"`
+}
+
+// GroupByReviewer regroups a shaped report by reviewer. Each parent comment is
+// attributed to its author and keeps its replies, including replies written by
+// other reviewers. Reviewers are sorted by login; reviews and comments keep
+// their order from the report.
+func GroupByReviewer(r Report) ReviewerReport {
+	groups := make(map[string]*ReviewerGroup)
+	group := func(login string) *ReviewerGroup {
+		g, ok := groups[login]
+		if !ok {
+			g = &ReviewerGroup{Login: login, Reviews: []ReportReview{}, Comments: []ReportComment{}}
+			groups[login] = g
+		}
+		return g
+	}
+
+	for _, review := range r.Reviews {
+		comments := review.Comments
+		review.Comments = nil
+		reviewer := group(review.AuthorLogin)
+		reviewer.Reviews = append(reviewer.Reviews, review)
+		for _, comment := range comments {
+			author := group(comment.AuthorLogin)
+			author.Comments = append(author.Comments, comment)
+		}
+	}
+
+	logins := make([]string, 0, len(groups))
+	for login := range groups {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	out := ReviewerReport{
+		SchemaVersion: r.SchemaVersion,
+		Meta:          r.Meta,
+		Reviewers:     make([]ReviewerGroup, 0, len(logins)),
+		Truncated:     r.Truncated,
+	}
+	for _, login := range logins {
+		out.Reviewers = append(out.Reviewers, *groups[login])
+	}
+	return out
+}
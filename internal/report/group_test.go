@@ -0,0 +1,72 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestGroupByReviewerAttributesParentComments(t *testing.T) {
+	input := report.Report{
+		SchemaVersion: report.SchemaVersion,
+		Reviews: []report.ReportReview{
+			{
+				ID:          "PRR_alice",
+				State:       report.StateChangesRequested,
+				AuthorLogin: "alice",
+				Comments: []report.ReportComment{
+					{
+						ThreadID:    "PRRT_shared",
+						Path:        "main.go",
+						AuthorLogin: "alice",
+						Body:        "Handle the error",
+						ThreadComments: []report.ThreadReply{
+							{AuthorLogin: "bob", Body: "Agreed"},
+							{AuthorLogin: "carol", Body: "Fixed"},
+						},
+					},
+				},
+			},
+			{
+				ID:          "PRR_bob",
+				State:       report.StateCommented,
+				AuthorLogin: "bob",
+				Comments: []report.ReportComment{
+					{ThreadID: "PRRT_bob", Path: "util.go", AuthorLogin: "bob", Body: "Rename", ThreadComments: []report.ThreadReply{}},
+				},
+			},
+			{ID: "PRR_alice2", State: report.StateApproved, AuthorLogin: "alice"},
+		},
+	}
+
+	grouped := report.GroupByReviewer(input)
+
+	data, err := json.Marshal(grouped)
+	if err != nil {
+		t.Fatalf("marshal grouped report: %v", err)
+	}
+	want := `{"schema_version":"1","reviewers":[` +
+		`{"login":"alice","reviews":[{"id":"PRR_alice","state":"CHANGES_REQUESTED","author_login":"alice"},{"id":"PRR_alice2","state":"APPROVED","author_login":"alice"}],` +
+		`"comments":[{"thread_id":"PRRT_shared","path":"main.go","author_login":"alice","body":"Handle the error","created_at":"","is_resolved":false,"is_outdated":false,` +
+		`"thread_comments":[{"author_login":"bob","body":"Agreed","created_at":""},{"author_login":"carol","body":"Fixed","created_at":""}]}]},` +
+		`{"login":"bob","reviews":[{"id":"PRR_bob","state":"COMMENTED","author_login":"bob"}],` +
+		`"comments":[{"thread_id":"PRRT_bob","path":"util.go","author_login":"bob","body":"Rename","created_at":"","is_resolved":false,"is_outdated":false,"thread_comments":[]}]}]}`
+	if string(data) != want {
+		t.Fatalf("unexpected grouping:\n got %s\nwant %s", data, want)
+	}
+	if len(input.Reviews[0].Comments) != 1 {
+		t.Fatalf("expected input report to be left untouched")
+	}
+}
+
+func TestGroupByReviewerEmptyReport(t *testing.T) {
+	data, err := json.Marshal(report.GroupByReviewer(report.Report{Reviews: []report.ReportReview{}, Truncated: true}))
+	if err != nil {
+		t.Fatalf("marshal grouped report: %v", err)
+	}
+	want := `{"reviewers":[],"truncated":true}`
+	if string(data) != want {
+		t.Fatalf("unexpected grouping for empty report: %s", data)
+	}
+}
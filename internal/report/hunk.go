@@ -0,0 +1,63 @@
+package report
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// HunkContext returns up to n diff lines on either side of line taken from
+// hunk, without the "@@" header. line is an old-file line number when side is
+// LEFT and a new-file line number otherwise. When line does not appear in the
+// hunk, the anchor falls back to the hunk's last line, which is where GitHub
+// ends the hunk for a review comment. Hunks shorter than the requested window
+// are returned whole. ok is false for empty or malformed hunks.
+func HunkContext(hunk string, line *int, side string, n int) (context string, ok bool) {
+	if n < 0 {
+		return "", false
+	}
+	lines := strings.Split(strings.TrimRight(hunk, "\n"), "\n")
+	if len(lines) == 0 {
+		return "", false
+	}
+	header := hunkHeaderRE.FindStringSubmatch(lines[0])
+	if header == nil {
+		return "", false
+	}
+	body := lines[1:]
+	if len(body) == 0 {
+		return "", false
+	}
+
+	// Count the lines of the requested side, skipping those only on the other.
+	start, other := header[3], "-"
+	if strings.EqualFold(side, "LEFT") {
+		start, other = header[1], "+"
+	}
+	current, _ := strconv.Atoi(start)
+	anchor := len(body) - 1
+	if line != nil {
+		for i, text := range body {
+			if strings.HasPrefix(text, other) || strings.HasPrefix(text, `\`) {
+				continue
+			}
+			if current == *line {
+				anchor = i
+				break
+			}
+			current++
+		}
+	}
+
+	from := anchor - n
+	if from < 0 {
+		from = 0
+	}
+	to := anchor + n + 1
+	if to > len(body) {
+		to = len(body)
+	}
+	return strings.Join(body[from:to], "\n"), true
+}
@@ -0,0 +1,81 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+const sampleHunk = "@@ -10,5 +10,6 @@ func main() {\n" +
+	" a\n" + // old 10, new 10
+	" b\n" + // old 11, new 11
+	"-old\n" + // old 12
+	"+c\n" + // new 12
+	"+d\n" + // new 13
+	" e\n" + // old 13, new 14
+	" f" // old 14, new 15
+
+func TestHunkContext(t *testing.T) {
+	cases := []struct {
+		name string
+		hunk string
+		line *int
+		side string
+		n    int
+		want string
+		ok   bool
+	}{
+		{name: "window around added line", hunk: sampleHunk, line: intPtr(12), n: 1, want: "-old\n+c\n+d", ok: true},
+		{name: "zero context keeps anchor only", hunk: sampleHunk, line: intPtr(14), n: 0, want: " e", ok: true},
+		{name: "window clipped at start", hunk: sampleHunk, line: intPtr(10), n: 2, want: " a\n b\n-old", ok: true},
+		{name: "hunk shorter than window", hunk: sampleHunk, line: intPtr(13), n: 50, want: " a\n b\n-old\n+c\n+d\n e\n f", ok: true},
+		{name: "left side counts old lines", hunk: sampleHunk, line: intPtr(12), side: "LEFT", n: 1, want: " b\n-old\n+c", ok: true},
+		{name: "left side skips added lines", hunk: sampleHunk, line: intPtr(13), side: "LEFT", n: 0, want: " e", ok: true},
+		{name: "missing line anchors at end", hunk: sampleHunk, line: intPtr(99), n: 1, want: " e\n f", ok: true},
+		{name: "nil line anchors at end", hunk: sampleHunk + "\n", line: nil, n: 0, want: " f", ok: true},
+		{name: "header without counts", hunk: "@@ -1 +1 @@\n+only", line: intPtr(1), n: 3, want: "+only", ok: true},
+		{name: "header only", hunk: "@@ -1,0 +1,0 @@", line: intPtr(1), n: 1, ok: false},
+		{name: "malformed header", hunk: "not a hunk\n+x", line: intPtr(1), n: 1, ok: false},
+		{name: "empty", hunk: "", line: intPtr(1), n: 1, ok: false},
+		{name: "negative window", hunk: sampleHunk, line: intPtr(12), n: -1, ok: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := report.HunkContext(tc.hunk, tc.line, tc.side, tc.n)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("%s: HunkContext = (%q, %v), want (%q, %v)", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestBuildReportAttachesContextLines(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	hunk := sampleHunk
+	threads := []report.Thread{{
+		ID:   "T1",
+		Path: "main.go",
+		Line: intPtr(12),
+		Comments: []report.ThreadComment{{
+			NodeID:           "C1",
+			DatabaseID:       11,
+			Body:             "why?",
+			DiffHunk:         &hunk,
+			AuthorLogin:      "alice",
+			ReviewDatabaseID: intPtr(1),
+		}},
+	}}
+
+	out := report.BuildReport(reviews, threads, report.FilterOptions{ContextLines: 1})
+	comment := out.Reviews[0].Comments[0]
+	if comment.Context == nil || *comment.Context != "-old\n+c\n+d" {
+		t.Fatalf("expected context around line 12, got %v", comment.Context)
+	}
+	if comment.DiffHunk != nil {
+		t.Fatalf("expected diff_hunk omitted without IncludeDiffHunk, got %q", *comment.DiffHunk)
+	}
+
+	out = report.BuildReport(reviews, threads, report.FilterOptions{})
+	if ctx := out.Reviews[0].Comments[0].Context; ctx != nil {
+		t.Fatalf("expected no context by default, got %q", *ctx)
+	}
+}
@@ -7,34 +7,97 @@ const reportQuery = `query Report(
   $states: [PullRequestReviewState!],
   $firstReviews: Int,
   $firstThreads: Int,
-  $firstComments: Int
+  $firstComments: Int,
+  $afterThreads: String,
+  $withCost: Boolean = false
 ) {
+  rateLimit @include(if: $withCost) {
+    cost
+    remaining
+  }
   repository(owner: $owner, name: $name) {
     pullRequest(number: $number) {
       reviews(first: $firstReviews, states: $states) {
+        pageInfo { hasNextPage }
         nodes {
           id
           state
           body
           submittedAt
           databaseId
-          author { login }
+          author {
+            login
+            ... on User { databaseId }
+          }
         }
       }
-      reviewThreads(first: $firstThreads) {
+      timelineItems(itemTypes: [REVIEW_DISMISSED_EVENT], last: 100) {
+        nodes {
+          ... on ReviewDismissedEvent {
+            dismissalMessage
+            actor { login }
+            review { id }
+          }
+        }
+      }
+` + reviewThreadsSelection + `
+    }
+  }
+}`
+
+// reportThreadsQuery fetches later pages of review threads. Reviews and the
+// dismissal timeline come with the first page, so only threads are requested.
+const reportThreadsQuery = `query ReportThreads(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $firstThreads: Int,
+  $firstComments: Int,
+  $afterThreads: String,
+  $withCost: Boolean = false
+) {
+  rateLimit @include(if: $withCost) {
+    cost
+    remaining
+  }
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+` + reviewThreadsSelection + `
+    }
+  }
+}`
+
+// reviewThreadsSelection is the reviewThreads connection shared by both
+// report queries.
+const reviewThreadsSelection = `      reviewThreads(first: $firstThreads, after: $afterThreads) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
         nodes {
           id
           path
           line
+          diffSide
           isResolved
           isOutdated
+          resolvedBy { login }
           comments(first: $firstComments) {
+            totalCount
+            pageInfo { hasNextPage }
             nodes {
               id
               databaseId
               body
+              diffHunk
               createdAt
-              author { login }
+              viewerDidAuthor
+              commit { oid }
+              originalCommit { oid }
+              author {
+                login
+                ... on User { databaseId }
+              }
               pullRequestReview {
                 id
                 state
@@ -46,8 +109,8 @@ const reportQuery = `query Report(
               }
             }
           }
+          latestComment: comments(last: 1) {
+            nodes { viewerDidAuthor }
+          }
         }
-      }
-    }
-  }
-}`
+      }`
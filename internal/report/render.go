@@ -0,0 +1,142 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Palette decorates fragments of the text rendering. Implementations must only
+// wrap the text they are given so the plain structure stays intact.
+type Palette interface {
+	ReviewState(state State, text string) string
+	ResolvedThread(text string) string
+}
+
+// PlainPalette leaves text untouched.
+type PlainPalette struct{}
+
+// ReviewState returns text unchanged.
+func (PlainPalette) ReviewState(_ State, text string) string { return text }
+
+// ResolvedThread returns text unchanged.
+func (PlainPalette) ResolvedThread(text string) string { return text }
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiDim   = "\x1b[2m"
+)
+
+// ANSIPalette colors approvals green, change requests red, and dims resolved threads.
+type ANSIPalette struct{}
+
+// ReviewState colors text according to the review state.
+func (ANSIPalette) ReviewState(state State, text string) string {
+	switch state {
+	case StateApproved:
+		return ansiGreen + text + ansiReset
+	case StateChangesRequested:
+		return ansiRed + text + ansiReset
+	default:
+		return text
+	}
+}
+
+// ResolvedThread dims text belonging to a resolved thread.
+func (ANSIPalette) ResolvedThread(text string) string {
+	return ansiDim + text + ansiReset
+}
+
+// RenderText writes a human-readable rendering of the report. A nil palette
+// renders plain text.
+func RenderText(w io.Writer, r Report, palette Palette) error {
+	if palette == nil {
+		palette = PlainPalette{}
+	}
+	bw := bufio.NewWriter(w)
+
+	if r.Meta != nil {
+		fmt.Fprintf(bw, "%s (%s) generated %s by %s\n\n", r.Meta.PR, r.Meta.Host, r.Meta.GeneratedAt, r.Meta.ToolVersion)
+	}
+	if len(r.Reviews) == 0 {
+		fmt.Fprintln(bw, "No reviews.")
+	}
+
+	for i, review := range r.Reviews {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		header := fmt.Sprintf("%s %s", palette.ReviewState(review.State, string(review.State)), review.AuthorLogin)
+		if review.SubmittedAt != nil {
+			header += " at " + *review.SubmittedAt
+		}
+		fmt.Fprintln(bw, header)
+		if review.Body != nil {
+			writeIndented(bw, "  ", *review.Body, nil)
+		}
+
+		for _, comment := range review.Comments {
+			var style func(string) string
+			if comment.IsResolved {
+				style = palette.ResolvedThread
+			}
+			writeIndented(bw, "  ", fmt.Sprintf("- %s [%s] %s:", commentLocation(comment), threadStatus(comment), comment.AuthorLogin), style)
+			writeIndented(bw, "    ", comment.Body, style)
+			for _, reply := range comment.ThreadComments {
+				header := "> " + reply.AuthorLogin + ":"
+				if reply.Repeated > 1 {
+					header = fmt.Sprintf("> %s (repeated %d times):", reply.AuthorLogin, reply.Repeated)
+				}
+				writeIndented(bw, "    ", header, style)
+				writeIndented(bw, "      ", reply.Body, style)
+			}
+		}
+		for _, stub := range review.CollapsedThreads {
+			location := stub.Path
+			if stub.Line != nil {
+				location = fmt.Sprintf("%s:%d", stub.Path, *stub.Line)
+			}
+			writeIndented(bw, "  ", fmt.Sprintf("- %s [resolved, %d comments collapsed]", location, stub.CommentCount), palette.ResolvedThread)
+		}
+	}
+
+	if r.Truncated {
+		fmt.Fprintln(bw, "\n(truncated)")
+	}
+	return bw.Flush()
+}
+
+func commentLocation(comment ReportComment) string {
+	if comment.Line == nil {
+		return comment.Path
+	}
+	return fmt.Sprintf("%s:%d", comment.Path, *comment.Line)
+}
+
+func threadStatus(comment ReportComment) string {
+	status := "unresolved"
+	if comment.IsResolved {
+		status = "resolved"
+	}
+	if comment.IsOutdated {
+		status += ", outdated"
+	}
+	if comment.OutdatedRelative != nil && *comment.OutdatedRelative {
+		status += ", outdated since commit"
+	}
+	return status
+}
+
+// writeIndented writes each line of text with the given indent, styling the
+// line content (not the indent) when style is set.
+func writeIndented(w io.Writer, indent, text string, style func(string) string) {
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if style != nil && line != "" {
+			line = style(line)
+		}
+		fmt.Fprintln(w, strings.TrimRight(indent+line, " "))
+	}
+}
@@ -0,0 +1,103 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func renderFixture() report.Report {
+	approved := "LGTM"
+	submitted := "2025-12-03T10:00:00Z"
+	return report.Report{Reviews: []report.ReportReview{
+		{ID: "R1", State: report.StateApproved, AuthorLogin: "alice", Body: &approved, SubmittedAt: &submitted},
+		{
+			ID:          "R2",
+			State:       report.StateChangesRequested,
+			AuthorLogin: "bob",
+			Comments: []report.ReportComment{
+				{
+					Path:        "main.go",
+					Line:        intPtr(42),
+					AuthorLogin: "bob",
+					Body:        "Handle the error",
+					IsResolved:  true,
+					ThreadComments: []report.ThreadReply{
+						{AuthorLogin: "alice", Body: "Done"},
+					},
+				},
+				{Path: "README.md", AuthorLogin: "bob", Body: "Typo", IsOutdated: true, ThreadComments: []report.ThreadReply{}},
+			},
+		},
+	}}
+}
+
+func TestRenderTextPlain(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.RenderText(&buf, renderFixture(), nil); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"APPROVED alice at 2025-12-03T10:00:00Z",
+		"  LGTM",
+		"",
+		"CHANGES_REQUESTED bob",
+		"  - main.go:42 [resolved] bob:",
+		"    Handle the error",
+		"    > alice:",
+		"      Done",
+		"  - README.md [unresolved, outdated] bob:",
+		"    Typo",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Fatalf("unexpected rendering:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+type tagPalette struct{}
+
+func (tagPalette) ReviewState(state report.State, text string) string {
+	return "<" + string(state) + ">" + text + "</>"
+}
+
+func (tagPalette) ResolvedThread(text string) string { return "<dim>" + text + "</>" }
+
+func TestRenderTextAppliesPalette(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.RenderText(&buf, renderFixture(), tagPalette{}); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<APPROVED>APPROVED</> alice",
+		"<CHANGES_REQUESTED>CHANGES_REQUESTED</> bob",
+		"  <dim>- main.go:42 [resolved] bob:</>",
+		"      <dim>Done</>",
+		"  - README.md [unresolved, outdated] bob:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rendering:\n%s", want, out)
+		}
+	}
+}
+
+func TestANSIPaletteColors(t *testing.T) {
+	p := report.ANSIPalette{}
+	if got := p.ReviewState(report.StateApproved, "x"); got != "\x1b[32mx\x1b[0m" {
+		t.Fatalf("unexpected approved color %q", got)
+	}
+	if got := p.ReviewState(report.StateChangesRequested, "x"); got != "\x1b[31mx\x1b[0m" {
+		t.Fatalf("unexpected changes requested color %q", got)
+	}
+	if got := p.ReviewState(report.StateCommented, "x"); got != "x" {
+		t.Fatalf("expected commented uncolored, got %q", got)
+	}
+	if got := p.ResolvedThread("x"); got != "\x1b[2mx\x1b[0m" {
+		t.Fatalf("unexpected resolved style %q", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/agynio/gh-pr-review/internal/buildinfo"
 	"github.com/agynio/gh-pr-review/internal/ghcli"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 )
@@ -14,111 +15,197 @@ const (
 	defaultFirstReviews  = 100
 	defaultFirstThreads  = 100
 	defaultFirstComments = 100
+	// MaxPerPage is the largest page size GitHub accepts for connections.
+	MaxPerPage = 100
 )
 
 // Service fetches and shapes pull request review reports.
 type Service struct {
 	API ghcli.API
+	// Now returns the current time used for report metadata.
+	Now func() time.Time
+	// Version returns the tool version recorded in report metadata.
+	Version func() string
 }
 
 // Options controls data retrieval and shaping for the report.
 type Options struct {
-	Reviewer             string
+	Reviewers            []string
+	ExcludeReviewers     []string
 	States               []State
 	StatesProvided       bool
+	ExcludeStates        []State
 	RequireUnresolved    bool
 	RequireNotOutdated   bool
+	RequireOutdated      bool
 	TailReplies          int
+	HeadReplies          int
 	IncludeCommentNodeID bool
+	IncludeDiffHunk      bool
+	IncludeAuthorID      bool
+	ContextLines         int
+	WithMeta             bool
+	Order                CommentOrder
+	// MaxThreads stops collecting review threads after N threads (0 = unlimited).
+	MaxThreads int
+	// PerPage sets the GraphQL page size for review threads (0 = default of
+	// 100). Reviews and thread comments are always read in one page of 100.
+	PerPage int
+	// MaxPages stops thread pagination after N pages and marks the report
+	// truncated when more remain (0 = unlimited).
+	MaxPages      int
+	MinSeverity   Severity
+	DropUnlabeled bool
+	Paths         []string
+	LineRange     *LineRange
+	ResolvedBy    string
+	NewSince      *time.Time
+	// RequireViewerAuthored keeps only threads the authenticated user commented in.
+	RequireViewerAuthored bool
+	// RequireUnreplied keeps only unresolved threads awaiting the authenticated user's reply.
+	RequireUnreplied bool
+	// DedupReplies collapses consecutive identical reply bodies within a thread.
+	DedupReplies bool
+	// IncludeViewerPending merges the authenticated user's pending review into the report.
+	IncludeViewerPending bool
+	// AllowGhostAuthors reports reviews and comments whose author account was
+	// deleted under GhostLogin instead of failing.
+	AllowGhostAuthors bool
+	// CollapseResolved replaces resolved threads with CollapsedThread stubs.
+	CollapseResolved bool
+	// ReportCost requests the GraphQL rate limit cost of the report queries,
+	// returned in Report.Cost.
+	ReportCost bool
+	// SinceCommit, a full or abbreviated commit SHA from the pull request,
+	// adds outdated_relative to parent comments measured against that commit.
+	SinceCommit string
 }
 
+// GhostLogin is the login GitHub shows for content whose author account was deleted.
+const GhostLogin = "ghost"
+
 // NewService constructs a report service using the provided GraphQL API client.
 func NewService(api ghcli.API) *Service {
-	return &Service{API: api}
+	return &Service{API: api, Now: time.Now, Version: buildinfo.ToolVersion}
 }
 
-// Fetch generates a review report for the given pull request.
+// Fetch generates a review report for the given pull request. Review threads
+// are paginated with PerPage; reviews and the comments of each thread are
+// read in one page of 100, and the report is marked truncated when more
+// remain.
 func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
+	firstThreads := defaultFirstThreads
+	if opts.PerPage > 0 {
+		firstThreads = opts.PerPage
+	}
 	variables := map[string]interface{}{
 		"owner":         pr.Owner,
 		"name":          pr.Repo,
 		"number":        pr.Number,
 		"firstReviews":  defaultFirstReviews,
-		"firstThreads":  defaultFirstThreads,
+		"firstThreads":  firstThreads,
 		"firstComments": defaultFirstComments,
 	}
 	if opts.StatesProvided {
-		states := make([]string, len(opts.States))
-		for i, st := range opts.States {
-			states[i] = string(st)
+		states := make([]string, 0, len(opts.States)+1)
+		hasPending := false
+		for _, st := range opts.States {
+			states = append(states, string(st))
+			hasPending = hasPending || st == StatePending
+		}
+		if opts.IncludeViewerPending && !hasPending {
+			states = append(states, string(StatePending))
 		}
 		variables["states"] = states
 	}
+	if opts.ReportCost {
+		variables["withCost"] = true
+	}
 
-	var response struct {
-		Repository *struct {
-			PullRequest *struct {
-				Reviews struct {
-					Nodes []struct {
-						ID          string  `json:"id"`
-						State       string  `json:"state"`
-						Body        *string `json:"body"`
-						SubmittedAt *string `json:"submittedAt"`
-						DatabaseID  *int    `json:"databaseId"`
-						Author      *struct {
-							Login string `json:"login"`
-						} `json:"author"`
-					} `json:"nodes"`
-				} `json:"reviews"`
-				ReviewThreads struct {
-					Nodes []struct {
-						ID         string `json:"id"`
-						Path       string `json:"path"`
-						Line       *int   `json:"line"`
-						IsResolved bool   `json:"isResolved"`
-						IsOutdated bool   `json:"isOutdated"`
-						Comments   struct {
-							Nodes []struct {
-								ID         string `json:"id"`
-								DatabaseID int    `json:"databaseId"`
-								Body       string `json:"body"`
-								CreatedAt  string `json:"createdAt"`
-								Author     *struct {
-									Login string `json:"login"`
-								} `json:"author"`
-								PullRequestReview *struct {
-									DatabaseID *int   `json:"databaseId"`
-									State      string `json:"state"`
-									ID         string `json:"id"`
-								} `json:"pullRequestReview"`
-								ReplyTo *struct {
-									ID         string `json:"id"`
-									DatabaseID int    `json:"databaseId"`
-								} `json:"replyTo"`
-							} `json:"nodes"`
-						} `json:"comments"`
-					} `json:"nodes"`
-				} `json:"reviewThreads"`
-			} `json:"pullRequest"`
-		} `json:"repository"`
-	}
-
-	if err := s.API.GraphQL(reportQuery, variables, &response); err != nil {
+	response, err := s.query(reportQuery, pr, variables)
+	if err != nil {
 		return Report{}, err
 	}
-
-	if response.Repository == nil || response.Repository.PullRequest == nil {
-		return Report{}, errors.New("pull request not found or inaccessible")
+	var cost *QueryCost
+	addCost := func(response *reportResponse) {
+		if response.RateLimit == nil {
+			return
+		}
+		if cost == nil {
+			cost = &QueryCost{}
+		}
+		cost.Points += response.RateLimit.Cost
+		cost.Remaining = response.RateLimit.Remaining
+		cost.Queries++
 	}
+	addCost(response)
 
 	prData := response.Repository.PullRequest
+	threadNodes := prData.ReviewThreads.Nodes
+	pageInfo := prData.ReviewThreads.PageInfo
+	truncated := prData.Reviews.PageInfo.HasNextPage
+	pages := 1
+	for {
+		if opts.MaxThreads > 0 && len(threadNodes) >= opts.MaxThreads {
+			truncated = truncated || len(threadNodes) > opts.MaxThreads || pageInfo.HasNextPage
+			threadNodes = threadNodes[:opts.MaxThreads]
+			break
+		}
+		if !pageInfo.HasNextPage {
+			break
+		}
+		if opts.MaxPages > 0 && pages >= opts.MaxPages {
+			truncated = true
+			break
+		}
+		cursor := strings.TrimSpace(pageInfo.EndCursor)
+		if cursor == "" {
+			return Report{}, errors.New("review thread pagination cursor missing")
+		}
+		next, err := s.query(reportThreadsQuery, pr, map[string]interface{}{
+			"owner":         pr.Owner,
+			"name":          pr.Repo,
+			"number":        pr.Number,
+			"firstThreads":  firstThreads,
+			"firstComments": defaultFirstComments,
+			"afterThreads":  cursor,
+			"withCost":      opts.ReportCost,
+		})
+		if err != nil {
+			return Report{}, err
+		}
+		addCost(next)
+		threadNodes = append(threadNodes, next.Repository.PullRequest.ReviewThreads.Nodes...)
+		pageInfo = next.Repository.PullRequest.ReviewThreads.PageInfo
+		pages++
+	}
+
+	dismissals := make(map[string]*Dismissal)
+	for _, event := range prData.TimelineItems.Nodes {
+		if event.Review == nil || event.Review.ID == "" {
+			continue
+		}
+		// Events arrive oldest first, so the latest dismissal of a review wins.
+		dismissal := &Dismissal{}
+		if event.DismissalMessage != nil && strings.TrimSpace(*event.DismissalMessage) != "" {
+			reason := *event.DismissalMessage
+			dismissal.Reason = &reason
+		}
+		if event.Actor != nil && event.Actor.Login != "" {
+			by := event.Actor.Login
+			dismissal.By = &by
+		}
+		dismissals[event.Review.ID] = dismissal
+	}
+
 	reviews := make([]Review, 0, len(prData.Reviews.Nodes))
 
 	for _, node := range prData.Reviews.Nodes {
 		if node.DatabaseID == nil {
 			return Report{}, errors.New("review missing databaseId")
 		}
-		if node.Author == nil || node.Author.Login == "" {
+		reviewAuthor, ok := resolveAuthor(node.Author, opts.AllowGhostAuthors)
+		if !ok {
 			return Report{}, errors.New("review missing author login")
 		}
 		state, ok := parseState(node.State)
@@ -129,9 +216,13 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 			ID:          node.ID,
 			State:       state,
 			Body:        node.Body,
-			AuthorLogin: node.Author.Login,
+			AuthorLogin: reviewAuthor.Login,
+			AuthorID:    reviewAuthor.DatabaseID,
 			DatabaseID:  *node.DatabaseID,
 		}
+		if state == StateDismissed {
+			review.Dismissal = dismissals[node.ID]
+		}
 		if node.SubmittedAt != nil && strings.TrimSpace(*node.SubmittedAt) != "" {
 			parsed, err := time.Parse(time.RFC3339, *node.SubmittedAt)
 			if err != nil {
@@ -142,22 +233,35 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 		reviews = append(reviews, review)
 	}
 
-	threads := make([]Thread, 0, len(prData.ReviewThreads.Nodes))
-	for _, node := range prData.ReviewThreads.Nodes {
+	threads := make([]Thread, 0, len(threadNodes))
+	for _, node := range threadNodes {
+		truncated = truncated || node.Comments.PageInfo.HasNextPage
 		thread := Thread{
 			ID:         node.ID,
 			Path:       node.Path,
 			Line:       node.Line,
+			DiffSide:   node.DiffSide,
 			IsResolved: node.IsResolved,
 			IsOutdated: node.IsOutdated,
 			Comments:   make([]ThreadComment, 0, len(node.Comments.Nodes)),
+
+			TotalComments: node.Comments.TotalCount,
+		}
+		if len(node.LatestComment.Nodes) > 0 {
+			viewerAuthored := node.LatestComment.Nodes[0].ViewerDidAuthor
+			thread.ViewerAuthoredLatest = &viewerAuthored
+		}
+		if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
+			login := node.ResolvedBy.Login
+			thread.ResolvedBy = &login
 		}
 
 		for _, comment := range node.Comments.Nodes {
 			if comment.ID == "" {
 				return Report{}, errors.New("comment missing id")
 			}
-			if comment.Author == nil || comment.Author.Login == "" {
+			commentAuthor, ok := resolveAuthor(comment.Author, opts.AllowGhostAuthors)
+			if !ok {
 				return Report{}, errors.New("comment missing author login")
 			}
 			createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
@@ -179,15 +283,26 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 				}
 			}
 
+			var diffHunk *string
+			if comment.DiffHunk != "" {
+				hunk := comment.DiffHunk
+				diffHunk = &hunk
+			}
+
 			thread.Comments = append(thread.Comments, ThreadComment{
 				NodeID:             comment.ID,
 				DatabaseID:         comment.DatabaseID,
 				Body:               comment.Body,
+				DiffHunk:           diffHunk,
 				CreatedAt:          createdAt,
-				AuthorLogin:        comment.Author.Login,
+				AuthorLogin:        commentAuthor.Login,
+				AuthorID:           commentAuthor.DatabaseID,
 				ReviewDatabaseID:   reviewDatabaseID,
 				ReplyToDatabaseID:  replyTo,
 				ReplyToCommentNode: replyToNode,
+				ViewerDidAuthor:    comment.ViewerDidAuthor,
+				CommitOID:          comment.Commit.oid(),
+				OriginalCommitOID:  comment.OriginalCommit.oid(),
 			})
 		}
 
@@ -195,15 +310,197 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 	}
 
 	filters := FilterOptions{
-		Reviewer:             opts.Reviewer,
-		States:               opts.States,
-		RequireUnresolved:    opts.RequireUnresolved,
-		RequireNotOutdated:   opts.RequireNotOutdated,
-		TailReplies:          opts.TailReplies,
-		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		Reviewers:             opts.Reviewers,
+		ExcludeReviewers:      opts.ExcludeReviewers,
+		States:                opts.States,
+		ExcludeStates:         opts.ExcludeStates,
+		RequireUnresolved:     opts.RequireUnresolved,
+		RequireNotOutdated:    opts.RequireNotOutdated,
+		RequireOutdated:       opts.RequireOutdated,
+		TailReplies:           opts.TailReplies,
+		HeadReplies:           opts.HeadReplies,
+		IncludeCommentNodeID:  opts.IncludeCommentNodeID,
+		IncludeDiffHunk:       opts.IncludeDiffHunk,
+		IncludeAuthorID:       opts.IncludeAuthorID,
+		ContextLines:          opts.ContextLines,
+		Order:                 opts.Order,
+		MinSeverity:           opts.MinSeverity,
+		DropUnlabeled:         opts.DropUnlabeled,
+		Paths:                 opts.Paths,
+		LineRange:             opts.LineRange,
+		ResolvedBy:            opts.ResolvedBy,
+		NewSince:              opts.NewSince,
+		RequireViewerAuthored: opts.RequireViewerAuthored,
+		RequireUnreplied:      opts.RequireUnreplied,
+		DedupReplies:          opts.DedupReplies,
+		IncludeViewerPending:  opts.IncludeViewerPending,
+		CollapseResolved:      opts.CollapseResolved,
+	}
+	if sha := strings.TrimSpace(opts.SinceCommit); sha != "" {
+		baseline, err := s.commitBaseline(pr, sha)
+		if err != nil {
+			return Report{}, err
+		}
+		filters.SinceCommit = baseline
 	}
 
-	return BuildReport(reviews, threads, filters), nil
+	result := BuildReport(reviews, threads, filters)
+	result.Truncated = truncated
+	result.Cost = cost
+	if opts.WithMeta {
+		result.Meta = s.meta(pr)
+	}
+	return result, nil
+}
+
+type reportResponse struct {
+	RateLimit *struct {
+		Cost      int `json:"cost"`
+		Remaining int `json:"remaining"`
+	} `json:"rateLimit"`
+	Repository *struct {
+		PullRequest *struct {
+			Reviews struct {
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+				Nodes []reviewNode `json:"nodes"`
+			} `json:"reviews"`
+			TimelineItems struct {
+				Nodes []dismissalNode `json:"nodes"`
+			} `json:"timelineItems"`
+			ReviewThreads struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []threadNode `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type reviewNode struct {
+	ID          string  `json:"id"`
+	State       string  `json:"state"`
+	Body        *string `json:"body"`
+	SubmittedAt *string `json:"submittedAt"`
+	DatabaseID  *int    `json:"databaseId"`
+	Author      *author `json:"author"`
+}
+
+// author is an actor; DatabaseID is only present for users, not bots or apps.
+type author struct {
+	Login      string `json:"login"`
+	DatabaseID *int64 `json:"databaseId"`
+}
+
+// resolveAuthor returns the actor behind a review or comment. GitHub sends a
+// null author once the account is deleted; with allowGhost that becomes
+// GhostLogin, otherwise ok is false.
+func resolveAuthor(a *author, allowGhost bool) (resolved author, ok bool) {
+	if a != nil && a.Login != "" {
+		return *a, true
+	}
+	if allowGhost {
+		return author{Login: GhostLogin}, true
+	}
+	return author{}, false
+}
+
+type dismissalNode struct {
+	DismissalMessage *string `json:"dismissalMessage"`
+	Actor            *struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Review *struct {
+		ID string `json:"id"`
+	} `json:"review"`
+}
+
+type threadNode struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Line       *int   `json:"line"`
+	DiffSide   string `json:"diffSide"`
+	IsResolved bool   `json:"isResolved"`
+	IsOutdated bool   `json:"isOutdated"`
+	ResolvedBy *struct {
+		Login string `json:"login"`
+	} `json:"resolvedBy"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+		PageInfo   struct {
+			HasNextPage bool `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Nodes []commentNode `json:"nodes"`
+	} `json:"comments"`
+	LatestComment struct {
+		Nodes []struct {
+			ViewerDidAuthor bool `json:"viewerDidAuthor"`
+		} `json:"nodes"`
+	} `json:"latestComment"`
+}
+
+type commentNode struct {
+	ID                string  `json:"id"`
+	DatabaseID        int     `json:"databaseId"`
+	Body              string  `json:"body"`
+	DiffHunk          string  `json:"diffHunk"`
+	CreatedAt         string  `json:"createdAt"`
+	ViewerDidAuthor   bool    `json:"viewerDidAuthor"`
+	Author            *author `json:"author"`
+	PullRequestReview *struct {
+		DatabaseID *int   `json:"databaseId"`
+		State      string `json:"state"`
+		ID         string `json:"id"`
+	} `json:"pullRequestReview"`
+	ReplyTo *struct {
+		ID         string `json:"id"`
+		DatabaseID int    `json:"databaseId"`
+	} `json:"replyTo"`
+	Commit         *commitRef `json:"commit"`
+	OriginalCommit *commitRef `json:"originalCommit"`
+}
+
+type commitRef struct {
+	OID string `json:"oid"`
+}
+
+func (c *commitRef) oid() string {
+	if c == nil {
+		return ""
+	}
+	return c.OID
+}
+
+// query issues the report query and ensures the pull request was found.
+func (s *Service) query(query string, pr resolver.Identity, variables map[string]interface{}) (*reportResponse, error) {
+	var response reportResponse
+	if err := s.API.GraphQL(query, variables, &response); err != nil {
+		return nil, err
+	}
+	if response.Repository == nil || response.Repository.PullRequest == nil {
+		return nil, &resolver.NotFoundError{Identity: pr}
+	}
+	return &response, nil
+}
+
+func (s *Service) meta(pr resolver.Identity) *Meta {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	version := buildinfo.ToolVersion
+	if s.Version != nil {
+		version = s.Version
+	}
+	return &Meta{
+		GeneratedAt: now().UTC().Format(time.RFC3339),
+		ToolVersion: version(),
+		PR:          fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number),
+		Host:        pr.Host,
+	}
 }
 
 func parseState(raw string) (State, bool) {
@@ -216,6 +513,8 @@ func parseState(raw string) (State, bool) {
 		return StateCommented, true
 	case string(StateDismissed):
 		return StateDismissed, true
+	case string(StatePending):
+		return StatePending, true
 	default:
 		return "", false
 	}
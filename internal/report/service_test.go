@@ -2,8 +2,11 @@ package report
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	_ "embed"
 
@@ -13,13 +16,19 @@ import (
 //go:embed testdata/report_response.json
 var reportResponseFixture []byte
 
+//go:embed testdata/resolved_by_response.json
+var resolvedByFixture []byte
+
+//go:embed testdata/dismissed_response.json
+var dismissedFixture []byte
+
 func TestServiceFetchShapesReport(t *testing.T) {
 	fake := &stubAPI{t: t, payload: reportResponseFixture}
 	svc := NewService(fake)
 
 	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
 	result, err := svc.Fetch(identity, Options{
-		Reviewer:           "alice",
+		Reviewers:          []string{"alice"},
 		States:             []State{StateApproved, StateCommented},
 		StatesProvided:     true,
 		RequireNotOutdated: true,
@@ -95,6 +104,429 @@ func TestServiceFetchIncludesCommentNodeID(t *testing.T) {
 	}
 }
 
+func TestServiceFetchIncludesDiffHunkOnlyWhenRequested(t *testing.T) {
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	svc := NewService(&stubAPI{t: t, payload: reportResponseFixture})
+	plain, err := svc.Fetch(identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	for _, review := range plain.Reviews {
+		for _, comment := range review.Comments {
+			if comment.DiffHunk != nil {
+				t.Fatalf("expected diff_hunk omitted by default, got %q", *comment.DiffHunk)
+			}
+		}
+	}
+
+	withHunk, err := svc.Fetch(identity, Options{IncludeDiffHunk: true})
+	if err != nil {
+		t.Fatalf("fetch report with diff hunk: %v", err)
+	}
+	comment := withHunk.Reviews[0].Comments[0]
+	if comment.DiffHunk == nil || *comment.DiffHunk != "@@ -40,3 +40,4 @@" {
+		t.Fatalf("expected diff_hunk for parent comment, got %v", comment.DiffHunk)
+	}
+	if len(withHunk.Reviews) > 1 && withHunk.Reviews[1].Comments[0].DiffHunk != nil {
+		t.Fatal("expected diff_hunk omitted when GitHub returns none")
+	}
+}
+
+func TestServiceFetchWithMeta(t *testing.T) {
+	fake := &stubAPI{t: t, payload: reportResponseFixture}
+	svc := NewService(fake)
+	svc.Now = func() time.Time {
+		return time.Date(2025, 12, 4, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	}
+	svc.Version = func() string { return "v1.7.0" }
+
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Host: "github.com", Number: 51}
+	result, err := svc.Fetch(identity, Options{WithMeta: true})
+	if err != nil {
+		t.Fatalf("fetch report with meta: %v", err)
+	}
+	if result.Meta == nil {
+		t.Fatal("expected meta block")
+	}
+	if result.Meta.GeneratedAt != "2025-12-04T08:30:00Z" {
+		t.Fatalf("expected UTC generated_at, got %s", result.Meta.GeneratedAt)
+	}
+	if result.Meta.ToolVersion != "v1.7.0" {
+		t.Fatalf("expected tool_version v1.7.0, got %s", result.Meta.ToolVersion)
+	}
+	if result.Meta.PR != "agyn/sandbox#51" {
+		t.Fatalf("unexpected pr: %s", result.Meta.PR)
+	}
+	if result.Meta.Host != "github.com" {
+		t.Fatalf("unexpected host: %s", result.Meta.Host)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	meta, ok := decoded["meta"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected top-level meta object, got %#v", decoded["meta"])
+	}
+	for _, key := range []string{"generated_at", "tool_version", "pr", "host"} {
+		if _, ok := meta[key]; !ok {
+			t.Fatalf("expected meta.%s to be present", key)
+		}
+	}
+
+	plain, err := svc.Fetch(identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch report without meta: %v", err)
+	}
+	if plain.Meta != nil {
+		t.Fatalf("expected meta omitted by default, got %#v", plain.Meta)
+	}
+}
+
+// pendingReviewFixture extends the report fixture with carol's pending review R3 and its draft thread T3.
+func pendingReviewFixture(t *testing.T) []byte {
+	t.Helper()
+	fixture := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &fixture); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := fixture["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	reviews := pr["reviews"].(map[string]any)
+	reviews["nodes"] = append(reviews["nodes"].([]any), map[string]any{
+		"id":          "R3",
+		"state":       "PENDING",
+		"body":        "",
+		"submittedAt": nil,
+		"databaseId":  303,
+		"author":      map[string]any{"login": "carol"},
+	})
+	threads := pr["reviewThreads"].(map[string]any)
+	threads["nodes"] = append(threads["nodes"].([]any), map[string]any{
+		"id":         "T3",
+		"path":       "draft.go",
+		"line":       7,
+		"isResolved": false,
+		"isOutdated": false,
+		"comments": map[string]any{"nodes": []any{map[string]any{
+			"id":                "C501",
+			"databaseId":        501,
+			"body":              "Draft note",
+			"createdAt":         "2025-12-03T11:00:00Z",
+			"author":            map[string]any{"login": "carol"},
+			"pullRequestReview": map[string]any{"id": "R3", "state": "PENDING", "databaseId": 303},
+			"replyTo":           nil,
+		}}},
+	})
+	payload, err := json.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+	return payload
+}
+
+func TestServiceFetchIncludesPendingReviews(t *testing.T) {
+	svc := NewService(&stubAPI{t: t, payload: pendingReviewFixture(t)})
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	result, err := svc.Fetch(identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch default report: %v", err)
+	}
+	for _, review := range result.Reviews {
+		if review.State == StatePending {
+			t.Fatalf("expected pending reviews excluded by default, got %s", review.ID)
+		}
+	}
+
+	result, err = svc.Fetch(identity, Options{States: []State{StatePending}, StatesProvided: true})
+	if err != nil {
+		t.Fatalf("fetch pending report: %v", err)
+	}
+	if len(result.Reviews) != 1 {
+		t.Fatalf("expected only the pending review, got %d", len(result.Reviews))
+	}
+	pending := result.Reviews[0]
+	if pending.ID != "R3" || pending.State != StatePending {
+		t.Fatalf("unexpected pending review: %+v", pending)
+	}
+	if pending.SubmittedAt != nil {
+		t.Fatalf("expected pending review without submitted_at, got %v", *pending.SubmittedAt)
+	}
+	if len(pending.Comments) != 1 || pending.Comments[0].ThreadID != "T3" {
+		t.Fatalf("expected draft thread T3 attached to pending review, got %+v", pending.Comments)
+	}
+}
+
+func TestServiceFetchIncludeViewerPending(t *testing.T) {
+	fake := &stubAPI{t: t, payload: pendingReviewFixture(t)}
+	svc := NewService(fake)
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	result, err := svc.Fetch(identity, Options{IncludeViewerPending: true})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	var pending *ReportReview
+	for i, review := range result.Reviews {
+		if review.State == StatePending {
+			pending = &result.Reviews[i]
+			continue
+		}
+		if review.Pending {
+			t.Fatalf("expected submitted review %s not marked pending", review.ID)
+		}
+	}
+	if len(result.Reviews) < 2 {
+		t.Fatalf("expected submitted reviews kept alongside the pending one, got %d", len(result.Reviews))
+	}
+	if pending == nil || pending.ID != "R3" || !pending.Pending {
+		t.Fatalf("expected pending review R3 marked pending, got %+v", pending)
+	}
+	if len(pending.Comments) != 1 || pending.Comments[0].ThreadID != "T3" {
+		t.Fatalf("expected draft thread T3 under the pending review, got %+v", pending.Comments)
+	}
+
+	_, err = svc.Fetch(identity, Options{States: []State{StateApproved}, StatesProvided: true, IncludeViewerPending: true})
+	if err != nil {
+		t.Fatalf("fetch report with states: %v", err)
+	}
+	states, _ := fake.lastVariables["states"].([]string)
+	if strings.Join(states, ",") != "APPROVED,PENDING" {
+		t.Fatalf("expected PENDING added to requested states, got %#v", fake.lastVariables["states"])
+	}
+}
+
+func TestServiceFetchPaginatesThreads(t *testing.T) {
+	fake := &pagedStubAPI{t: t, pages: threadPages(t)}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch paginated report: %v", err)
+	}
+	if len(fake.cursors) != 2 || fake.cursors[0] != "" || fake.cursors[1] != "CURSOR_1" {
+		t.Fatalf("expected two page requests, got cursors %v", fake.cursors)
+	}
+	if fake.queries[0] != reportQuery || fake.queries[1] != reportThreadsQuery {
+		t.Fatal("expected later pages to request only review threads")
+	}
+	if _, ok := fake.variables[1]["firstReviews"]; ok {
+		t.Fatalf("expected no review variables on later pages, got %v", fake.variables[1])
+	}
+	if result.Truncated {
+		t.Fatal("expected untruncated report")
+	}
+	if got := countComments(result); got != 2 {
+		t.Fatalf("expected threads from both pages, got %d comments", got)
+	}
+}
+
+func TestServiceFetchMaxThreadsTruncates(t *testing.T) {
+	fake := &pagedStubAPI{t: t, pages: threadPages(t)}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{MaxThreads: 1})
+	if err != nil {
+		t.Fatalf("fetch capped report: %v", err)
+	}
+	if len(fake.cursors) != 1 {
+		t.Fatalf("expected collection to stop after the first page, got cursors %v", fake.cursors)
+	}
+	if !result.Truncated {
+		t.Fatal("expected truncated report")
+	}
+	if got := countComments(result); got != 1 {
+		t.Fatalf("expected a single thread, got %d comments", got)
+	}
+
+	result, err = NewService(&pagedStubAPI{t: t, pages: threadPages(t)}).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{MaxThreads: 2})
+	if err != nil {
+		t.Fatalf("fetch report at exact cap: %v", err)
+	}
+	if result.Truncated {
+		t.Fatal("expected no truncation when the cap equals the thread count")
+	}
+}
+
+func TestServiceFetchMaxPagesStopsPagination(t *testing.T) {
+	fake := &pagedStubAPI{t: t, pages: threadPages(t)}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{MaxPages: 1})
+	if err != nil {
+		t.Fatalf("fetch page-capped report: %v", err)
+	}
+	if len(fake.cursors) != 1 {
+		t.Fatalf("expected a single page request, got cursors %v", fake.cursors)
+	}
+	if !result.Truncated {
+		t.Fatal("expected truncated report when pages remain")
+	}
+	if got := countComments(result); got != 1 {
+		t.Fatalf("expected only the first page's thread, got %d comments", got)
+	}
+
+	fake = &pagedStubAPI{t: t, pages: threadPages(t)}
+	result, err = NewService(fake).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{MaxPages: 2})
+	if err != nil {
+		t.Fatalf("fetch report at exact page cap: %v", err)
+	}
+	if len(fake.cursors) != 2 {
+		t.Fatalf("expected both pages requested, got cursors %v", fake.cursors)
+	}
+	if result.Truncated {
+		t.Fatal("expected no truncation when the last page fits within --max-pages")
+	}
+}
+
+func TestServiceFetchPerPageSetsPageSizes(t *testing.T) {
+	fake := &pagedStubAPI{t: t, pages: threadPages(t)}
+	if _, err := NewService(fake).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{PerPage: 25}); err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	for page, variables := range fake.variables {
+		if got := variables["firstThreads"]; got != 25 {
+			t.Fatalf("expected firstThreads=25 on page %d, got %v", page+1, got)
+		}
+	}
+	// Reviews and thread comments are not paginated, so they keep full pages.
+	for _, key := range []string{"firstReviews", "firstComments"} {
+		if got := fake.variables[0][key]; got != 100 {
+			t.Fatalf("expected %s=100, got %v", key, got)
+		}
+	}
+
+	fake = &pagedStubAPI{t: t, pages: threadPages(t)}
+	if _, err := NewService(fake).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{}); err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if got := fake.variables[0]["firstThreads"]; got != 100 {
+		t.Fatalf("expected default page size 100, got %v", got)
+	}
+}
+
+func TestServiceFetchMarksTruncatedWhenReviewsOrCommentsRemain(t *testing.T) {
+	for name, mark := range map[string]func(pr map[string]any){
+		"reviews": func(pr map[string]any) {
+			pr["reviews"].(map[string]any)["pageInfo"] = map[string]any{"hasNextPage": true}
+		},
+		"comments": func(pr map[string]any) {
+			thread := pr["reviewThreads"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+			thread["comments"].(map[string]any)["pageInfo"] = map[string]any{"hasNextPage": true}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fixture := map[string]any{}
+			if err := json.Unmarshal(reportResponseFixture, &fixture); err != nil {
+				t.Fatalf("unmarshal fixture: %v", err)
+			}
+			mark(fixture["repository"].(map[string]any)["pullRequest"].(map[string]any))
+			payload, err := json.Marshal(fixture)
+			if err != nil {
+				t.Fatalf("marshal fixture: %v", err)
+			}
+
+			result, err := NewService(&stubAPI{t: t, payload: payload}).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+			if err != nil {
+				t.Fatalf("fetch report: %v", err)
+			}
+			if !result.Truncated {
+				t.Fatalf("expected truncated report when %s remain", name)
+			}
+		})
+	}
+
+	result, err := NewService(&stubAPI{t: t, payload: reportResponseFixture}).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if result.Truncated {
+		t.Fatal("expected complete report without remaining pages")
+	}
+}
+
+func TestServiceFetchKeepsReviewTruncationAtThreadCap(t *testing.T) {
+	fixture := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &fixture); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := fixture["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	pr["reviews"].(map[string]any)["pageInfo"] = map[string]any{"hasNextPage": true}
+	payload, err := json.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+
+	result, err := NewService(&stubAPI{t: t, payload: payload}).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{MaxThreads: 2})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if !result.Truncated {
+		t.Fatal("expected truncated report when reviews remain and the thread cap is met exactly")
+	}
+}
+
+// threadPages splits the fixture's two threads across two paginated responses.
+func threadPages(t *testing.T) [][]byte {
+	t.Helper()
+	pages := make([][]byte, 2)
+	for i := range pages {
+		fixture := map[string]any{}
+		if err := json.Unmarshal(reportResponseFixture, &fixture); err != nil {
+			t.Fatalf("unmarshal fixture: %v", err)
+		}
+		threads := fixture["repository"].(map[string]any)["pullRequest"].(map[string]any)["reviewThreads"].(map[string]any)
+		nodes := threads["nodes"].([]any)
+		threads["nodes"] = []any{nodes[i]}
+		threads["pageInfo"] = map[string]any{"hasNextPage": i == 0, "endCursor": fmt.Sprintf("CURSOR_%d", i+1)}
+		data, err := json.Marshal(fixture)
+		if err != nil {
+			t.Fatalf("marshal page: %v", err)
+		}
+		pages[i] = data
+	}
+	return pages
+}
+
+func countComments(r Report) int {
+	total := 0
+	for _, review := range r.Reviews {
+		total += len(review.Comments)
+	}
+	return total
+}
+
+type pagedStubAPI struct {
+	t         *testing.T
+	pages     [][]byte
+	cursors   []string
+	queries   []string
+	variables []map[string]interface{}
+}
+
+func (p *pagedStubAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+	p.t.Fatalf("unexpected REST call in report service test")
+	return nil
+}
+
+func (p *pagedStubAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	cursor, _ := variables["afterThreads"].(string)
+	p.cursors = append(p.cursors, cursor)
+	p.queries = append(p.queries, query)
+	p.variables = append(p.variables, variables)
+	index := len(p.cursors) - 1
+	if index >= len(p.pages) {
+		p.t.Fatalf("unexpected page request %d", index)
+	}
+	return json.Unmarshal(p.pages[index], result)
+}
+
 func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	broken := map[string]any{}
 	if err := json.Unmarshal(reportResponseFixture, &broken); err != nil {
@@ -124,6 +556,108 @@ func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	}
 }
 
+func TestServiceFetchGhostAuthors(t *testing.T) {
+	ghosted := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &ghosted); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := ghosted["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	review := pr["reviews"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	review["author"] = nil
+	thread := pr["reviewThreads"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	comment := thread["comments"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	comment["author"] = nil
+
+	modified, err := json.Marshal(ghosted)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+	svc := NewService(&stubAPI{t: t, payload: modified})
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	_, err = svc.Fetch(identity, Options{})
+	if err == nil || err.Error() != "review missing author login" {
+		t.Fatalf("expected strict author error by default, got %v", err)
+	}
+
+	result, err := svc.Fetch(identity, Options{AllowGhostAuthors: true, IncludeAuthorID: true})
+	if err != nil {
+		t.Fatalf("fetch with ghost authors: %v", err)
+	}
+	ghostReview := false
+	for _, r := range result.Reviews {
+		if r.ID != review["id"] {
+			continue
+		}
+		ghostReview = r.AuthorLogin == GhostLogin && r.AuthorID == nil
+	}
+	if !ghostReview {
+		t.Fatalf("expected review %v attributed to %q without an author id, got %+v", review["id"], GhostLogin, result.Reviews)
+	}
+	ghostComment := false
+	for _, r := range result.Reviews {
+		for _, c := range r.Comments {
+			if c.ThreadID == thread["id"] {
+				ghostComment = c.AuthorLogin == GhostLogin
+			}
+		}
+	}
+	if !ghostComment {
+		t.Fatalf("expected thread %v comment attributed to %q, got %+v", thread["id"], GhostLogin, result.Reviews)
+	}
+}
+
+func TestServiceFetchNotFoundIncludesIdentity(t *testing.T) {
+	svc := NewService(&stubAPI{t: t, payload: []byte(`{"repository":{"pullRequest":null}}`)})
+
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Host: "ghe.example.com", Number: 51}
+	_, err := svc.Fetch(identity, Options{})
+	if err == nil {
+		t.Fatal("expected not found error")
+	}
+	want := "pull request agyn/sandbox#51 on ghe.example.com not found or inaccessible (check spelling, access, and GH_HOST)"
+	if err.Error() != want {
+		t.Fatalf("expected pull request identity in error, got %v", err)
+	}
+	var notFound *resolver.NotFoundError
+	if !errors.As(err, &notFound) || notFound.Identity != identity {
+		t.Fatalf("expected *resolver.NotFoundError for %+v, got %#v", identity, err)
+	}
+}
+
+func TestServiceFetchFiltersByResolver(t *testing.T) {
+	svc := NewService(&stubAPI{t: t, payload: resolvedByFixture})
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	threadsFor := func(login string) string {
+		result, err := svc.Fetch(identity, Options{ResolvedBy: login})
+		if err != nil {
+			t.Fatalf("fetch report: %v", err)
+		}
+		if len(result.Reviews) != 1 {
+			t.Fatalf("expected 1 review, got %d", len(result.Reviews))
+		}
+		ids := make([]string, 0, len(result.Reviews[0].Comments))
+		for _, comment := range result.Reviews[0].Comments {
+			ids = append(ids, comment.ThreadID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := threadsFor(""); got != "T1,T2,T3" {
+		t.Fatalf("expected all threads without filter, got %s", got)
+	}
+	if got := threadsFor("bob"); got != "T1" {
+		t.Fatalf("expected case-insensitive match on bob, got %s", got)
+	}
+	if got := threadsFor("carol"); got != "T2" {
+		t.Fatalf("expected carol's thread, got %s", got)
+	}
+	if got := threadsFor("alice"); got != "" {
+		t.Fatalf("expected no threads resolved by alice, got %s", got)
+	}
+}
+
 type stubAPI struct {
 	t             *testing.T
 	payload       []byte
@@ -144,3 +678,22 @@ func (s *stubAPI) GraphQL(query string, variables map[string]interface{}, result
 	}
 	return json.Unmarshal(s.payload, result)
 }
+
+func TestServiceFetchIncludesDismissalForDismissedReviews(t *testing.T) {
+	svc := NewService(&stubAPI{t: t, payload: dismissedFixture})
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	result, err := svc.Fetch(identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	data, err := json.Marshal(result.Reviews)
+	if err != nil {
+		t.Fatalf("marshal reviews: %v", err)
+	}
+	want := `[{"id":"R1","state":"DISMISSED","body":"Needs changes","submitted_at":"2025-12-03T10:00:00Z","author_login":"alice","dismissal":{"reason":"Stale after rebase","by":"carol"}},` +
+		`{"id":"R2","state":"APPROVED","body":"LGTM","submitted_at":"2025-12-03T11:00:00Z","author_login":"bob"}]`
+	if string(data) != want {
+		t.Fatalf("unexpected reviews:\n got %s\nwant %s", data, want)
+	}
+}
@@ -0,0 +1,66 @@
+package report
+
+import "strings"
+
+// Severity ranks a review comment by the tag that prefixes its body.
+type Severity int
+
+// Severities in ascending order; the zero value means "no threshold".
+const (
+	SeverityNit Severity = iota + 1
+	SeveritySuggestion
+	SeverityWarning
+	SeverityBlocker
+)
+
+var severityNames = map[string]Severity{
+	"nit":        SeverityNit,
+	"suggestion": SeveritySuggestion,
+	"warning":    SeverityWarning,
+	"blocker":    SeverityBlocker,
+}
+
+// String returns the lowercase tag name for the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityNit:
+		return "nit"
+	case SeveritySuggestion:
+		return "suggestion"
+	case SeverityWarning:
+		return "warning"
+	case SeverityBlocker:
+		return "blocker"
+	default:
+		return ""
+	}
+}
+
+// ParseSeverityName resolves a severity by name (case-insensitive).
+func ParseSeverityName(name string) (Severity, bool) {
+	sev, ok := severityNames[strings.ToLower(strings.TrimSpace(name))]
+	return sev, ok
+}
+
+// ParseSeverity extracts the severity from a leading "[tag]" in a comment body,
+// such as "[blocker] missing nil check". Unknown or missing tags report false.
+func ParseSeverity(body string) (Severity, bool) {
+	trimmed := strings.TrimSpace(body)
+	if !strings.HasPrefix(trimmed, "[") {
+		return 0, false
+	}
+	end := strings.Index(trimmed, "]")
+	if end < 0 {
+		return 0, false
+	}
+	return ParseSeverityName(trimmed[1:end])
+}
+
+// meetsSeverity reports whether a comment body passes the severity filters.
+func meetsSeverity(body string, min Severity, dropUnlabeled bool) bool {
+	sev, ok := ParseSeverity(body)
+	if !ok {
+		return !dropUnlabeled
+	}
+	return sev >= min
+}
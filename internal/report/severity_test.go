@@ -0,0 +1,71 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestParseSeverity(t *testing.T) {
+	cases := []struct {
+		body string
+		want report.Severity
+		ok   bool
+	}{
+		{body: "[blocker] missing nil check", want: report.SeverityBlocker, ok: true},
+		{body: "  [NIT] spacing", want: report.SeverityNit, ok: true},
+		{body: "[Suggestion]: extract helper", want: report.SeveritySuggestion, ok: true},
+		{body: "[warning]", want: report.SeverityWarning, ok: true},
+		{body: "[ blocker ] padded tag", want: report.SeverityBlocker, ok: true},
+		{body: "[question] why?", ok: false},
+		{body: "nit: no brackets", ok: false},
+		{body: "see [blocker] later in text", ok: false},
+		{body: "[blocker unterminated", ok: false},
+		{body: "", ok: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := report.ParseSeverity(tc.body)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("ParseSeverity(%q) = (%v, %v), want (%v, %v)", tc.body, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestBuildReportFiltersBySeverity(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	threads := []report.Thread{
+		severityThread("T1", "[nit] rename", 1),
+		severityThread("T2", "[suggestion] extract", 2),
+		severityThread("T3", "[warning] racy", 3),
+		severityThread("T4", "[blocker] panics", 4),
+		severityThread("T5", "untagged remark", 5),
+	}
+
+	all := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if got := strings.Join(threadIDs(all.Reviews[0].Comments), ","); got != "T1,T2,T3,T4,T5" {
+		t.Fatalf("expected all comments without threshold, got %s", got)
+	}
+
+	warning := report.BuildReport(reviews, threads, report.FilterOptions{MinSeverity: report.SeverityWarning})
+	if got := strings.Join(threadIDs(warning.Reviews[0].Comments), ","); got != "T3,T4,T5" {
+		t.Fatalf("expected warning and above plus unlabeled, got %s", got)
+	}
+
+	dropped := report.BuildReport(reviews, threads, report.FilterOptions{MinSeverity: report.SeverityWarning, DropUnlabeled: true})
+	if got := strings.Join(threadIDs(dropped.Reviews[0].Comments), ","); got != "T3,T4" {
+		t.Fatalf("expected unlabeled comment dropped, got %s", got)
+	}
+
+	labeledOnly := report.BuildReport(reviews, threads, report.FilterOptions{DropUnlabeled: true})
+	if got := strings.Join(threadIDs(labeledOnly.Reviews[0].Comments), ","); got != "T1,T2,T3,T4" {
+		t.Fatalf("expected only labeled comments, got %s", got)
+	}
+}
+
+func severityThread(id, body string, minute int) report.Thread {
+	thread := parentOnlyThread(id, "main.go", intPtr(minute), minute, 1)
+	thread.Comments[0].Body = body
+	return thread
+}
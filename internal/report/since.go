@@ -0,0 +1,125 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+const pullRequestCommitsQuery = `query PullRequestCommits($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      commits(first: 100, after: $after) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes {
+          commit { oid }
+        }
+      }
+    }
+  }
+}`
+
+// CommitBaseline is the commit that --since-commit measures outdatedness
+// against, together with the pull request's commits in push order.
+type CommitBaseline struct {
+	OID   string
+	order map[string]int
+}
+
+// NewCommitBaseline resolves sha, full or abbreviated, among commits (oldest
+// first) and records their order.
+func NewCommitBaseline(sha string, commits []string) (*CommitBaseline, error) {
+	prefix := strings.ToLower(strings.TrimSpace(sha))
+	baseline := &CommitBaseline{order: make(map[string]int, len(commits))}
+	matches := 0
+	for i, oid := range commits {
+		baseline.order[oid] = i
+		if strings.HasPrefix(strings.ToLower(oid), prefix) {
+			baseline.OID = oid
+			matches++
+		}
+	}
+	switch {
+	case matches == 0:
+		return nil, fmt.Errorf("commit %s is not part of the pull request", sha)
+	case matches > 1:
+		return nil, fmt.Errorf("commit %s is ambiguous; use a longer SHA", sha)
+	}
+	return baseline, nil
+}
+
+// outdatedRelative reports whether a thread anchored by parent no longer
+// applies at the baseline commit, as GitHub's isOutdated would if the
+// baseline were the head. It returns nil when that cannot be told: the
+// comment was written after the baseline, or GitHub does not expose the
+// commits it was written against or last applied to.
+func (b *CommitBaseline) outdatedRelative(parent ThreadComment, threadOutdated bool) *bool {
+	base := b.order[b.OID]
+	original, ok := b.order[parent.OriginalCommitOID]
+	if !ok || original > base {
+		return nil
+	}
+	outdated := false
+	if original == base || parent.CommitOID == b.OID || !threadOutdated {
+		return &outdated
+	}
+	// An outdated comment stops following pushes at the last commit it applied
+	// to; it was already outdated at the baseline if that commit came before.
+	current, ok := b.order[parent.CommitOID]
+	if !ok {
+		return nil
+	}
+	outdated = current < base
+	return &outdated
+}
+
+// commitBaseline lists the pull request's commits and resolves sha among them.
+func (s *Service) commitBaseline(pr resolver.Identity, sha string) (*CommitBaseline, error) {
+	variables := map[string]interface{}{
+		"owner":  pr.Owner,
+		"name":   pr.Repo,
+		"number": pr.Number,
+	}
+	var commits []string
+	for {
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					Commits struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							Commit commitRef `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(pullRequestCommitsQuery, variables, &response); err != nil {
+			return nil, fmt.Errorf("list pull request commits: %w", err)
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, &resolver.NotFoundError{Identity: pr}
+		}
+		connection := response.Repository.PullRequest.Commits
+		for _, node := range connection.Nodes {
+			commits = append(commits, node.Commit.OID)
+		}
+		if !connection.PageInfo.HasNextPage {
+			break
+		}
+		cursor := strings.TrimSpace(connection.PageInfo.EndCursor)
+		if cursor == "" {
+			return nil, errors.New("commit pagination cursor missing")
+		}
+		variables["after"] = cursor
+	}
+	return NewCommitBaseline(sha, commits)
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+//go:embed testdata/since_commit_response.json
+var sinceCommitFixture []byte
+
+var sinceCommitOIDs = []string{
+	"1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+	"2222222bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+	"3333333ccccccccccccccccccccccccccccccccc",
+	"4444444ddddddddddddddddddddddddddddddddd",
+}
+
+// commitsStubAPI serves the report fixture and the pull request's commits,
+// split over two pages.
+type commitsStubAPI struct {
+	stubAPI
+	commitPages int
+}
+
+func (c *commitsStubAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	if query != pullRequestCommitsQuery {
+		return c.stubAPI.GraphQL(query, variables, result)
+	}
+	c.commitPages++
+	page := sinceCommitOIDs[:2]
+	pageInfo := map[string]interface{}{"hasNextPage": true, "endCursor": "c2"}
+	if variables["after"] == "c2" {
+		page = sinceCommitOIDs[2:]
+		pageInfo = map[string]interface{}{"hasNextPage": false}
+	}
+	nodes := make([]map[string]interface{}, len(page))
+	for i, oid := range page {
+		nodes[i] = map[string]interface{}{"commit": map[string]interface{}{"oid": oid}}
+	}
+	data, err := json.Marshal(map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+		"commits": map[string]interface{}{"nodes": nodes, "pageInfo": pageInfo},
+	}}})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestServiceFetchSinceCommitMarksOutdatedRelative(t *testing.T) {
+	api := &commitsStubAPI{stubAPI: stubAPI{t: t, payload: sinceCommitFixture}}
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+
+	result, err := NewService(api).Fetch(identity, Options{SinceCommit: "2222222"})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if api.commitPages != 2 {
+		t.Fatalf("expected both commit pages to be fetched, got %d", api.commitPages)
+	}
+
+	got := map[string]string{}
+	for _, comment := range result.Reviews[0].Comments {
+		switch {
+		case comment.OutdatedRelative == nil:
+			got[comment.ThreadID] = "unknown"
+		case *comment.OutdatedRelative:
+			got[comment.ThreadID] = "outdated"
+		default:
+			got[comment.ThreadID] = "current"
+		}
+	}
+	want := map[string]string{
+		"T1": "current",  // still applies at the head
+		"T2": "outdated", // stopped applying before the baseline
+		"T3": "current",  // written against the baseline itself
+		"T4": "unknown",  // written after the baseline
+		"T5": "unknown",  // GitHub no longer exposes its commits
+	}
+	for id, status := range want {
+		if got[id] != status {
+			t.Fatalf("thread %s: expected %s, got %s (all: %v)", id, status, got[id], got)
+		}
+	}
+
+	plain, err := NewService(&stubAPI{t: t, payload: sinceCommitFixture}).Fetch(identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	data, err := json.Marshal(plain)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	if strings.Contains(string(data), "outdated_relative") {
+		t.Fatalf("expected outdated_relative omitted without --since-commit, got %s", data)
+	}
+}
+
+func TestNewCommitBaselineResolvesAbbreviatedSHA(t *testing.T) {
+	baseline, err := NewCommitBaseline("3333333C", sinceCommitOIDs)
+	if err != nil {
+		t.Fatalf("resolve baseline: %v", err)
+	}
+	if baseline.OID != sinceCommitOIDs[2] {
+		t.Fatalf("expected %s, got %s", sinceCommitOIDs[2], baseline.OID)
+	}
+
+	if _, err := NewCommitBaseline("9999999", sinceCommitOIDs); err == nil || err.Error() != "commit 9999999 is not part of the pull request" {
+		t.Fatalf("expected missing commit error, got %v", err)
+	}
+	ambiguous := []string{"abcdef01", "abcdef02"}
+	if _, err := NewCommitBaseline("abcdef0", ambiguous); err == nil || err.Error() != "commit abcdef0 is ambiguous; use a longer SHA" {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+}
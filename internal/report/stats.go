@@ -0,0 +1,67 @@
+package report
+
+import "sort"
+
+// Stats aggregates counts over a shaped report for dashboards.
+type Stats struct {
+	Reviews       map[State]int `json:"reviews"`
+	Threads       ThreadStats   `json:"threads"`
+	CommentsTotal int           `json:"comments_total"`
+	Reviewers     []string      `json:"reviewers"`
+}
+
+// ThreadStats counts review threads by resolution state. Outdated threads are
+// also counted as resolved or unresolved.
+type ThreadStats struct {
+	Resolved   int `json:"resolved"`
+	Unresolved int `json:"unresolved"`
+	Outdated   int `json:"outdated"`
+}
+
+// Summarize computes aggregate counts for a report. Comment totals follow
+// threadCommentCount and add the comments of collapsed threads, which are
+// also counted as resolved threads.
+func Summarize(r Report) Stats {
+	stats := Stats{
+		Reviews:   make(map[State]int),
+		Reviewers: []string{},
+	}
+
+	seen := make(map[string]struct{})
+	for _, review := range r.Reviews {
+		stats.Reviews[review.State]++
+		if _, ok := seen[review.AuthorLogin]; !ok {
+			seen[review.AuthorLogin] = struct{}{}
+			stats.Reviewers = append(stats.Reviewers, review.AuthorLogin)
+		}
+
+		for _, comment := range review.Comments {
+			if comment.IsResolved {
+				stats.Threads.Resolved++
+			} else {
+				stats.Threads.Unresolved++
+			}
+			if comment.IsOutdated {
+				stats.Threads.Outdated++
+			}
+			stats.CommentsTotal += threadCommentCount(comment)
+		}
+		for _, collapsed := range review.CollapsedThreads {
+			stats.Threads.Resolved++
+			stats.CommentsTotal += collapsed.CommentCount
+		}
+	}
+
+	sort.Strings(stats.Reviewers)
+	return stats
+}
+
+// threadCommentCount counts a parent comment and the replies retained under
+// it. A reply collapsed by DedupReplies counts as every reply it stands for.
+func threadCommentCount(comment ReportComment) int {
+	count := 1
+	for _, reply := range comment.ThreadComments {
+		count += max(1, reply.Repeated)
+	}
+	return count
+}
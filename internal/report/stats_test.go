@@ -0,0 +1,70 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestSummarize(t *testing.T) {
+	input := report.Report{Reviews: []report.ReportReview{
+		{
+			State:       report.StateChangesRequested,
+			AuthorLogin: "bob",
+			Comments: []report.ReportComment{
+				{ThreadID: "T1", IsResolved: true, ThreadComments: []report.ThreadReply{{}, {}}},
+				{ThreadID: "T2", IsOutdated: true, ThreadComments: []report.ThreadReply{}},
+			},
+		},
+		{State: report.StateApproved, AuthorLogin: "alice"},
+		{
+			State:       report.StateCommented,
+			AuthorLogin: "bob",
+			Comments: []report.ReportComment{
+				{ThreadID: "T3", IsResolved: true, IsOutdated: true, ThreadComments: []report.ThreadReply{{}}},
+			},
+		},
+	}}
+
+	stats := report.Summarize(input)
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("marshal stats: %v", err)
+	}
+	want := `{"reviews":{"APPROVED":1,"CHANGES_REQUESTED":1,"COMMENTED":1},"threads":{"resolved":2,"unresolved":1,"outdated":2},"comments_total":6,"reviewers":["alice","bob"]}`
+	if string(data) != want {
+		t.Fatalf("unexpected stats:\n got %s\nwant %s", data, want)
+	}
+}
+
+func TestSummarizeCountsCollapsedThreadsAndRepeatedReplies(t *testing.T) {
+	input := report.Report{Reviews: []report.ReportReview{{
+		State:       report.StateCommented,
+		AuthorLogin: "bob",
+		Comments: []report.ReportComment{
+			{ThreadID: "T1", ThreadComments: []report.ThreadReply{{Repeated: 4}, {}}},
+		},
+		CollapsedThreads: []report.CollapsedThread{{ThreadID: "T2", Resolved: true, CommentCount: 3}},
+	}}}
+
+	stats := report.Summarize(input)
+	if stats.CommentsTotal != 9 {
+		t.Fatalf("expected 9 comments (1 parent, 4 repeated + 1 replies, 3 collapsed), got %d", stats.CommentsTotal)
+	}
+	if stats.Threads.Resolved != 1 || stats.Threads.Unresolved != 1 {
+		t.Fatalf("expected the collapsed thread counted as resolved, got %+v", stats.Threads)
+	}
+}
+
+func TestSummarizeEmptyReport(t *testing.T) {
+	data, err := json.Marshal(report.Summarize(report.Report{Reviews: []report.ReportReview{}}))
+	if err != nil {
+		t.Fatalf("marshal stats: %v", err)
+	}
+	want := `{"reviews":{},"threads":{"resolved":0,"unresolved":0,"outdated":0},"comments_total":0,"reviewers":[]}`
+	if string(data) != want {
+		t.Fatalf("unexpected stats for empty report: %s", data)
+	}
+}
@@ -0,0 +1,46 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+const teamMembersPerPage = 100
+
+// TeamMembers returns the logins of every member of team, given as
+// "org/team-slug", paging through the REST team members endpoint. Listing a
+// team needs read access to the organization (the read:org scope).
+func TeamMembers(api ghcli.API, team string) ([]string, error) {
+	org, slug, ok := strings.Cut(strings.TrimSpace(team), "/")
+	org, slug = strings.TrimSpace(org), strings.TrimSpace(slug)
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return nil, fmt.Errorf("invalid team %q: expected org/team-slug", team)
+	}
+
+	path := fmt.Sprintf("orgs/%s/teams/%s/members", org, slug)
+	params := map[string]string{"per_page": strconv.Itoa(teamMembersPerPage)}
+	logins := make([]string, 0)
+	err := ghcli.RESTPaginate(api, "GET", path, params, func(item json.RawMessage) error {
+		var member struct {
+			Login string `json:"login"`
+		}
+		if err := json.Unmarshal(item, &member); err != nil {
+			return fmt.Errorf("decode team member: %w", err)
+		}
+		if login := strings.TrimSpace(member.Login); login != "" {
+			logins = append(logins, login)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list members of team %s/%s: %w", org, slug, err)
+	}
+	if len(logins) == 0 {
+		return nil, fmt.Errorf("team %s/%s has no members", org, slug)
+	}
+	return logins, nil
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type teamStubAPI struct {
+	t       *testing.T
+	members map[string][]string
+	calls   int
+}
+
+func (s *teamStubAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	s.calls++
+	if method != "GET" || path != "orgs/octo/teams/core/members" {
+		return errors.New("unexpected REST call: " + method + " " + path)
+	}
+	if params["per_page"] != "100" {
+		s.t.Fatalf("expected per_page=100, got %q", params["per_page"])
+	}
+	page := make([]map[string]string, 0)
+	for _, login := range s.members[params["page"]] {
+		page = append(page, map[string]string{"login": login})
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (s *teamStubAPI) GraphQL(string, map[string]interface{}, interface{}) error {
+	s.t.Fatalf("unexpected GraphQL call in team test")
+	return nil
+}
+
+func TestTeamMembersPaginates(t *testing.T) {
+	full := make([]string, teamMembersPerPage)
+	for i := range full {
+		full[i] = fmt.Sprintf("user%d", i)
+	}
+	api := &teamStubAPI{t: t, members: map[string][]string{"1": full, "2": {"alice"}}}
+
+	logins, err := TeamMembers(api, " octo/core ")
+	if err != nil {
+		t.Fatalf("team members: %v", err)
+	}
+	if api.calls != 2 {
+		t.Fatalf("expected two pages requested, got %d", api.calls)
+	}
+	if len(logins) != teamMembersPerPage+1 || logins[teamMembersPerPage] != "alice" {
+		t.Fatalf("expected both pages of members, got %d (last %q)", len(logins), logins[len(logins)-1])
+	}
+}
+
+func TestTeamMembersErrors(t *testing.T) {
+	for _, team := range []string{"octo", "octo/", "/core", "octo/core/extra"} {
+		if _, err := TeamMembers(&teamStubAPI{t: t}, team); err == nil || !strings.Contains(err.Error(), "expected org/team-slug") {
+			t.Fatalf("expected format error for %q, got %v", team, err)
+		}
+	}
+
+	_, err := TeamMembers(&teamStubAPI{t: t, members: map[string][]string{}}, "octo/core")
+	if err == nil || err.Error() != "team octo/core has no members" {
+		t.Fatalf("expected empty team error, got %v", err)
+	}
+
+	_, err = TeamMembers(&teamStubAPI{t: t}, "octo/other")
+	if err == nil || !strings.HasPrefix(err.Error(), "list members of team octo/other: ") {
+		t.Fatalf("expected wrapped REST error, got %v", err)
+	}
+}
+
+func TestTeamMembersIgnoresBlankLogins(t *testing.T) {
+	api := &teamStubAPI{t: t, members: map[string][]string{"1": {"alice", " ", "bob"}}}
+	logins, err := TeamMembers(api, "octo/core")
+	if err != nil {
+		t.Fatalf("team members: %v", err)
+	}
+	if !reflect.DeepEqual(logins, []string{"alice", "bob"}) {
+		t.Fatalf("unexpected logins: %v", logins)
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+// TruncationSuffix is appended to bodies shortened by TruncateBodies.
+const TruncationSuffix = "…[truncated]"
+
+// TruncateBodies shortens review, comment, and reply bodies longer than max
+// runes, appending TruncationSuffix and setting Truncated on each shortened
+// entry. Multi-byte characters are never split. The input report is left
+// untouched; max <= 0 returns it unchanged.
+func TruncateBodies(r Report, max int) Report {
+	if max <= 0 {
+		return r
+	}
+
+	reviews := make([]ReportReview, len(r.Reviews))
+	for i, review := range r.Reviews {
+		if review.Body != nil {
+			body, truncated := truncateBody(*review.Body, max)
+			review.Body = &body
+			review.Truncated = truncated
+		}
+		if review.Comments != nil {
+			comments := make([]ReportComment, len(review.Comments))
+			for j, comment := range review.Comments {
+				comment.Body, comment.Truncated = truncateBody(comment.Body, max)
+				if comment.ThreadComments != nil {
+					replies := make([]ThreadReply, len(comment.ThreadComments))
+					for k, reply := range comment.ThreadComments {
+						reply.Body, reply.Truncated = truncateBody(reply.Body, max)
+						replies[k] = reply
+					}
+					comment.ThreadComments = replies
+				}
+				comments[j] = comment
+			}
+			review.Comments = comments
+		}
+		reviews[i] = review
+	}
+	r.Reviews = reviews
+	return r
+}
+
+// truncateBody cuts body after max runes and reports whether it did.
+func truncateBody(body string, max int) (string, bool) {
+	count := 0
+	for i := range body {
+		if count == max {
+			return body[:i] + TruncationSuffix, true
+		}
+		count++
+	}
+	return body, false
+}
@@ -0,0 +1,60 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestTruncateBodiesCountsRunes(t *testing.T) {
+	reviewBody := "Looks good"
+	input := report.Report{
+		Reviews: []report.ReportReview{{
+			ID:    "R1",
+			State: report.StateCommented,
+			Body:  &reviewBody,
+			Comments: []report.ReportComment{{
+				ThreadID: "T1",
+				// "ü" and "🚀" are multi-byte; the cut falls right after the rocket.
+				Body: "Grüß 🚀 rest of a long comment",
+				ThreadComments: []report.ThreadReply{
+					{Body: "Ja 🚀!!"},
+					{Body: "Ja 🚀!!!"},
+				},
+			}},
+		}},
+	}
+
+	got := report.TruncateBodies(input, 6)
+
+	comment := got.Reviews[0].Comments[0]
+	if comment.Body != "Grüß 🚀"+report.TruncationSuffix || !comment.Truncated {
+		t.Fatalf("unexpected comment truncation: %q (truncated=%v)", comment.Body, comment.Truncated)
+	}
+	if !utf8.ValidString(comment.Body) {
+		t.Fatalf("truncated body is not valid UTF-8: %q", comment.Body)
+	}
+	if reply := comment.ThreadComments[0]; reply.Body != "Ja 🚀!!" || reply.Truncated {
+		t.Fatalf("expected a body of exactly max runes to be kept, got %q (truncated=%v)", reply.Body, reply.Truncated)
+	}
+	if reply := comment.ThreadComments[1]; reply.Body != "Ja 🚀!!"+report.TruncationSuffix || !reply.Truncated {
+		t.Fatalf("expected a body one rune over max to be cut, got %q (truncated=%v)", reply.Body, reply.Truncated)
+	}
+	if review := got.Reviews[0]; *review.Body != "Looks "+report.TruncationSuffix || !review.Truncated {
+		t.Fatalf("unexpected review truncation: %q", *review.Body)
+	}
+
+	if input.Reviews[0].Comments[0].Body != "Grüß 🚀 rest of a long comment" || *input.Reviews[0].Body != "Looks good" {
+		t.Fatalf("expected input report to be left untouched")
+	}
+
+	data, err := json.Marshal(report.TruncateBodies(input, 100).Reviews[0].Comments[0])
+	if err != nil {
+		t.Fatalf("marshal comment: %v", err)
+	}
+	if string(data) != `{"thread_id":"T1","path":"","author_login":"","body":"Grüß 🚀 rest of a long comment","created_at":"","is_resolved":false,"is_outdated":false,"thread_comments":[{"author_login":"","body":"Ja 🚀!!","created_at":""},{"author_login":"","body":"Ja 🚀!!!","created_at":""}]}` {
+		t.Fatalf("expected untruncated bodies without markers, got %s", data)
+	}
+}
@@ -0,0 +1,55 @@
+package resolver
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var permalinkAnchorRE = regexp.MustCompile(`^(discussion_r|r|pullrequestreview-)([0-9]+)$`)
+
+// AnchorKind identifies what a pull request permalink fragment points at.
+type AnchorKind string
+
+const (
+	// AnchorComment marks review comment anchors ("#discussion_r<id>" or "#r<id>").
+	AnchorComment AnchorKind = "comment"
+	// AnchorReview marks review anchors ("#pullrequestreview-<id>").
+	AnchorReview AnchorKind = "review"
+)
+
+// Anchor is the database identifier carried in a permalink fragment.
+type Anchor struct {
+	Kind AnchorKind
+	ID   int64
+}
+
+// ParsePermalink splits a pull request permalink such as
+// https://github.com/o/r/pull/9#discussion_r123456 into the pull request
+// identity and the comment or review database ID from its fragment.
+func ParsePermalink(raw string) (Identity, Anchor, error) {
+	id, err := parsePullURL(raw)
+	if err != nil {
+		return Identity{}, Anchor{}, fmt.Errorf("invalid permalink %q: %w", raw, err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Identity{}, Anchor{}, fmt.Errorf("invalid permalink %q: %w", raw, err)
+	}
+
+	matches := permalinkAnchorRE.FindStringSubmatch(u.Fragment)
+	if matches == nil {
+		return Identity{}, Anchor{}, fmt.Errorf("invalid permalink %q: expected a #discussion_r<id> or #pullrequestreview-<id> anchor", raw)
+	}
+	value, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil || value <= 0 {
+		return Identity{}, Anchor{}, fmt.Errorf("invalid permalink %q: anchor id out of range", raw)
+	}
+
+	kind := AnchorComment
+	if matches[1] == "pullrequestreview-" {
+		kind = AnchorReview
+	}
+	return id, Anchor{Kind: kind, ID: value}, nil
+}
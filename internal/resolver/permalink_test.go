@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePermalink(t *testing.T) {
+	want := Identity{Owner: "o", Repo: "r", Host: "github.com", Number: 9}
+
+	cases := []struct {
+		raw    string
+		anchor Anchor
+	}{
+		{"https://github.com/o/r/pull/9#discussion_r123456", Anchor{Kind: AnchorComment, ID: 123456}},
+		{"https://github.com/o/r/pull/9/files#r123456", Anchor{Kind: AnchorComment, ID: 123456}},
+		{"https://github.com/o/r/pull/9/files?diff=split#discussion_r42", Anchor{Kind: AnchorComment, ID: 42}},
+		{"https://github.com/o/r/pull/9#pullrequestreview-987654321", Anchor{Kind: AnchorReview, ID: 987654321}},
+	}
+	for _, tc := range cases {
+		id, anchor, err := ParsePermalink(tc.raw)
+		require.NoError(t, err, tc.raw)
+		assert.Equal(t, want, id, tc.raw)
+		assert.Equal(t, tc.anchor, anchor, tc.raw)
+	}
+}
+
+func TestParsePermalinkEnterpriseHost(t *testing.T) {
+	id, anchor, err := ParsePermalink("https://GHE.example.com/o/r/pull/3#discussion_r7")
+	require.NoError(t, err)
+	assert.Equal(t, "ghe.example.com", id.Host)
+	assert.Equal(t, int64(7), anchor.ID)
+}
+
+func TestParsePermalinkRejectsMissingOrUnknownAnchors(t *testing.T) {
+	for _, raw := range []string{
+		"https://github.com/o/r/pull/9",
+		"https://github.com/o/r/pull/9#issuecomment-5",
+		"https://github.com/o/r/pull/9#discussion_rabc",
+		"https://github.com/o/r/pull/9#discussion_r99999999999999999999",
+		"https://github.com/o/r/issues/9#discussion_r1",
+	} {
+		_, _, err := ParsePermalink(raw)
+		require.Error(t, err, raw)
+		assert.Contains(t, err.Error(), "invalid permalink", raw)
+	}
+}
@@ -11,7 +11,9 @@ import (
 )
 
 var (
-	pullURLRE = regexp.MustCompile(`^/([^/]+)/([^/]+)/pull/([0-9]+)(?:/.*)?$`)
+	pullURLRE       = regexp.MustCompile(`^/([^/]+)/([^/]+)/pull/([0-9]+)(?:/.*)?$`)
+	pullShorthandRE = regexp.MustCompile(`^([^/#]+)/([^/#]+)/pull/([0-9]+)$`)
+	repoNameRE      = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
 )
 
 // Identity represents a fully-resolved pull request reference.
@@ -22,6 +24,31 @@ type Identity struct {
 	Number int
 }
 
+// URL returns the web URL of the pull request, defaulting to github.com when no host is set.
+func (i Identity) URL() string {
+	return fmt.Sprintf("https://%s/%s/%s/pull/%d", sanitizeHost(i.Host), i.Owner, i.Repo, i.Number)
+}
+
+// NotFoundError reports a pull request that does not exist or that the
+// current credentials cannot see. Err carries the underlying API error, if any.
+type NotFoundError struct {
+	Identity Identity
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("pull request %s/%s#%d on %s not found or inaccessible (check spelling, access, and GH_HOST)",
+		e.Identity.Owner, e.Identity.Repo, e.Identity.Number, sanitizeHost(e.Identity.Host))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
 // NormalizeSelector ensures that either an explicit selector or --pr flag is present and mutually consistent.
 func NormalizeSelector(selector string, prFlag int) (string, error) {
 	selector = strings.TrimSpace(selector)
@@ -44,13 +71,19 @@ func NormalizeSelector(selector string, prFlag int) (string, error) {
 	}
 
 	if _, err := parsePullURL(selector); err == nil {
+		return canonicalPullURL(selector), nil
+	}
+
+	if _, err := parsePullShorthand(selector); err == nil {
 		return selector, nil
 	}
 
-	return "", fmt.Errorf("invalid pull request selector %q: must be a pull request URL or number", selector)
+	return "", fmt.Errorf("invalid pull request selector %q: must be a pull request URL or number, or owner/repo/pull/<number>", selector)
 }
 
-// Resolve interprets a selector, optional repo flag, and host (GH_HOST) into a concrete pull request identity.
+// Resolve interprets a selector, optional repo flag, and host (GH_HOST) into a
+// concrete pull request identity. Numeric and owner/repo/pull/<number>
+// selectors take the given host; URLs keep their own.
 func Resolve(selector, repoFlag, host string) (Identity, error) {
 	selector = strings.TrimSpace(selector)
 	repoFlag = strings.TrimSpace(repoFlag)
@@ -61,6 +94,17 @@ func Resolve(selector, repoFlag, host string) (Identity, error) {
 	}
 
 	if id, err := parsePullURL(selector); err == nil {
+		if err := validateRepoName(id.Owner, id.Repo); err != nil {
+			return Identity{}, err
+		}
+		return id, nil
+	}
+
+	if id, err := parsePullShorthand(selector); err == nil {
+		if err := validateRepoName(id.Owner, id.Repo); err != nil {
+			return Identity{}, err
+		}
+		id.Host = host
 		return id, nil
 	}
 
@@ -69,12 +113,18 @@ func Resolve(selector, repoFlag, host string) (Identity, error) {
 		if err != nil {
 			return Identity{}, fmt.Errorf("--repo must be owner/repo when using numeric selectors: %w", err)
 		}
+		if err := validateRepoName(owner, repo); err != nil {
+			return Identity{}, err
+		}
 		return Identity{Owner: owner, Repo: repo, Host: host, Number: n}, nil
 	}
 
 	return Identity{}, fmt.Errorf("invalid pull request selector: %q", selector)
 }
 
+// parsePullURL extracts an identity from a pull request URL. Query strings and
+// fragments (for example "?diff=split#discussion_r123") are ignored, as are
+// trailing sub-pages such as /files, /commits, or /checks.
 func parsePullURL(raw string) (Identity, error) {
 	u, err := url.Parse(raw)
 	if err != nil {
@@ -96,10 +146,42 @@ func parsePullURL(raw string) (Identity, error) {
 	}, nil
 }
 
+// parsePullShorthand extracts an identity without a host from the
+// "owner/repo/pull/42" form some tools emit. Unlike "owner/repo#42" it keeps
+// the URL's path layout, so it cannot be confused with a repository name.
+func parsePullShorthand(selector string) (Identity, error) {
+	matches := pullShorthandRE.FindStringSubmatch(selector)
+	if matches == nil {
+		return Identity{}, errors.New("not an owner/repo/pull/<number> selector")
+	}
+	number, err := strconv.Atoi(matches[3])
+	if err != nil || number <= 0 {
+		return Identity{}, errors.New("invalid pull request number")
+	}
+	return Identity{Owner: matches[1], Repo: matches[2], Number: number}, nil
+}
+
+// canonicalPullURL drops the query string and fragment from a pull request URL
+// so anchors like "#discussion_r123" never travel further as part of the selector.
+func canonicalPullURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
 func matchesNumber(selector string, target int) bool {
 	if id, err := parsePullURL(selector); err == nil {
 		return id.Number == target
 	}
+	if id, err := parsePullShorthand(selector); err == nil {
+		return id.Number == target
+	}
 	if n, err := strconv.Atoi(selector); err == nil {
 		return n == target
 	}
@@ -129,6 +211,17 @@ func splitRepo(repoFlag string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// validateRepoName rejects owners and repositories with characters GitHub does
+// not allow, so typos fail here instead of as opaque API errors.
+func validateRepoName(owner, repo string) error {
+	for _, part := range []string{owner, repo} {
+		if !repoNameRE.MatchString(part) || part == "." || part == ".." {
+			return fmt.Errorf("invalid repository name %q: owner and repo may only contain letters, digits, '.', '-', and '_'", owner+"/"+repo)
+		}
+	}
+	return nil
+}
+
 func sanitizeHost(raw string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
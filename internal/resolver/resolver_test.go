@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,129 @@ func TestResolveNumberRequiresRepo(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}, id)
 }
+
+func TestResolveValidatesRepositoryName(t *testing.T) {
+	for _, repo := range []string{"octo/demo", "Octo-Org/my_repo.go", "a1/b.c-d_e"} {
+		_, err := Resolve("7", repo, "github.com")
+		assert.NoError(t, err, repo)
+	}
+
+	for _, repo := range []string{"foo bar/baz qux", "octo/de mo", "octo/demo?x", "octo/..", "octo/demo/extra", "/demo", "octo/"} {
+		_, err := Resolve("7", repo, "github.com")
+		assert.Error(t, err, repo)
+	}
+
+	_, err := Resolve("7", "foo bar/baz", "github.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid repository name "foo bar/baz"`)
+
+	_, err = Resolve("https://github.com/oc%20to/demo/pull/1", "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid repository name")
+}
+
+func TestResolveURLWithQueryFragmentAndSuffix(t *testing.T) {
+	want := Identity{Owner: "o", Repo: "r", Host: "github.com", Number: 9}
+	selectors := []string{
+		"https://github.com/o/r/pull/9?diff=split",
+		"https://github.com/o/r/pull/9#discussion_r123",
+		"https://github.com/o/r/pull/9?diff=split#discussion_r123",
+		"https://github.com/o/r/pull/9/",
+		"https://github.com/o/r/pull/9/files",
+		"https://github.com/o/r/pull/9/files?w=1#diff-abc123",
+		"https://github.com/o/r/pull/9/commits",
+		"https://github.com/o/r/pull/9/commits/0123abc",
+		"https://github.com/o/r/pull/9/checks?check_run_id=42",
+	}
+
+	for _, selector := range selectors {
+		id, err := Resolve(selector, "", "")
+		require.NoError(t, err, selector)
+		assert.Equal(t, want, id, selector)
+
+		normalized, err := NormalizeSelector(selector, 9)
+		require.NoError(t, err, selector)
+		assert.NotContains(t, normalized, "?", selector)
+		assert.NotContains(t, normalized, "#", selector)
+
+		id, err = Resolve(normalized, "", "")
+		require.NoError(t, err, selector)
+		assert.Equal(t, want, id, selector)
+	}
+}
+
+func TestResolvePullShorthand(t *testing.T) {
+	id, err := Resolve("octo/demo/pull/42", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 42}, id)
+
+	id, err = Resolve("octo/demo/pull/42", "other/repo", "ghe.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "ghe.example.com", Number: 42}, id)
+
+	selector, err := NormalizeSelector("octo/demo/pull/42", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "octo/demo/pull/42", selector)
+
+	_, err = NormalizeSelector("octo/demo/pull/42", 7)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match --pr=7")
+
+	for _, selector := range []string{
+		"octo/demo#42",
+		"octo/demo/pull/42/files",
+		"octo/demo/pull/0",
+		"github.com/octo/demo/pull/42",
+		"octo/demo/issues/42",
+	} {
+		_, err := NormalizeSelector(selector, 0)
+		require.Error(t, err, selector)
+		_, err = Resolve(selector, "", "")
+		require.Error(t, err, selector)
+	}
+
+	_, err = Resolve("oc to/demo/pull/42", "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid repository name")
+}
+
+func TestNormalizeSelectorRejectsFragmentOnly(t *testing.T) {
+	_, err := NormalizeSelector("#discussion_r123", 0)
+	require.Error(t, err)
+
+	_, err = NormalizeSelector("https://github.com/o/r/pull/9#discussion_r123", 123)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match --pr=123")
+}
+
+func TestResolveRejectsNonPullURLs(t *testing.T) {
+	for _, selector := range []string{
+		"https://github.com/o/r/pull/9abc",
+		"https://github.com/o/r/issues/9",
+		"https://github.com/o/r/pull/?n=9",
+	} {
+		_, err := Resolve(selector, "", "")
+		require.Error(t, err, selector)
+	}
+}
+
+func TestIdentityURL(t *testing.T) {
+	id := Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}
+	assert.Equal(t, "https://github.com/octo/demo/pull/7", id.URL())
+
+	id.Host = ""
+	assert.Equal(t, "https://github.com/octo/demo/pull/7", id.URL(), "default host")
+
+	id.Host = "GHE.Example.com:8443"
+	assert.Equal(t, "https://ghe.example.com/octo/demo/pull/7", id.URL(), "enterprise host")
+}
+
+func TestNotFoundErrorNamesPullRequest(t *testing.T) {
+	err := &NotFoundError{Identity: Identity{Owner: "octo", Repo: "demo", Number: 7}}
+	assert.EqualError(t, err, "pull request octo/demo#7 on github.com not found or inaccessible (check spelling, access, and GH_HOST)")
+
+	cause := errors.New("HTTP 404: Not Found")
+	err = &NotFoundError{Identity: Identity{Owner: "octo", Repo: "demo", Host: "ghe.example.com", Number: 7}, Err: cause}
+	assert.EqualError(t, err, "pull request octo/demo#7 on ghe.example.com not found or inaccessible (check spelling, access, and GH_HOST): HTTP 404: Not Found")
+	assert.ErrorIs(t, err, cause)
+}
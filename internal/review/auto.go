@@ -0,0 +1,124 @@
+package review
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// AutoEventOptions tunes how AutoEvent maps pending comments to an event.
+type AutoEventOptions struct {
+	// RequestChanges submits REQUEST_CHANGES instead of COMMENT when the
+	// pending review has unresolved threads.
+	RequestChanges bool
+}
+
+// AutoEventDecision records the event chosen by AutoEvent and why.
+type AutoEventDecision struct {
+	Event             string
+	UnresolvedThreads int
+}
+
+// AutoEvent picks the submission event for a pending review from its threads:
+// any unresolved thread containing a comment from the review yields COMMENT
+// (or REQUEST_CHANGES with opts.RequestChanges); otherwise APPROVE.
+func (s *Service) AutoEvent(pr resolver.Identity, reviewID string, opts AutoEventOptions) (*AutoEventDecision, error) {
+	reviewID = strings.TrimSpace(reviewID)
+	if reviewID == "" {
+		return nil, errors.New("review id is required")
+	}
+
+	const query = `query PendingReviewThreads($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes {
+          isResolved
+          comments(first: 100) {
+            nodes { pullRequestReview { id } }
+          }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+	type threadNode struct {
+		IsResolved bool `json:"isResolved"`
+		Comments   struct {
+			Nodes []struct {
+				PullRequestReview *struct {
+					ID string `json:"id"`
+				} `json:"pullRequestReview"`
+			} `json:"nodes"`
+		} `json:"comments"`
+	}
+
+	var response struct {
+		Repository *struct {
+			PullRequest *struct {
+				ReviewThreads struct {
+					Nodes    []threadNode `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	unresolved := 0
+	var cursor *string
+	for {
+		variables := map[string]interface{}{
+			"owner":  pr.Owner,
+			"name":   pr.Repo,
+			"number": pr.Number,
+		}
+		if cursor != nil {
+			variables["cursor"] = *cursor
+		}
+
+		response.Repository = nil
+		if err := s.API.GraphQL(query, variables, &response); err != nil {
+			return nil, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, &resolver.NotFoundError{Identity: pr}
+		}
+
+		threads := response.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			if thread.IsResolved {
+				continue
+			}
+			for _, comment := range thread.Comments.Nodes {
+				if comment.PullRequestReview != nil && comment.PullRequestReview.ID == reviewID {
+					unresolved++
+					break
+				}
+			}
+		}
+
+		if !threads.PageInfo.HasNextPage || threads.PageInfo.EndCursor == "" {
+			break
+		}
+		next := threads.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	decision := &AutoEventDecision{Event: "APPROVE", UnresolvedThreads: unresolved}
+	if unresolved > 0 {
+		decision.Event = "COMMENT"
+		if opts.RequestChanges {
+			decision.Event = "REQUEST_CHANGES"
+		}
+	}
+	return decision, nil
+}
@@ -0,0 +1,89 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func threadPayload(resolved bool, reviewIDs ...string) map[string]interface{} {
+	comments := make([]map[string]interface{}, 0, len(reviewIDs))
+	for _, id := range reviewIDs {
+		comments = append(comments, map[string]interface{}{"pullRequestReview": map[string]interface{}{"id": id}})
+	}
+	return map[string]interface{}{
+		"isResolved": resolved,
+		"comments":   map[string]interface{}{"nodes": comments},
+	}
+}
+
+func autoEventAPI(t *testing.T, pages ...[]map[string]interface{}) *fakeAPI {
+	call := 0
+	return &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "PendingReviewThreads")
+		require.Less(t, call, len(pages))
+		if call > 0 {
+			assert.Equal(t, "cursor-1", variables["cursor"])
+		}
+		hasNext := call < len(pages)-1
+		nodes := pages[call]
+		call++
+		return assign(result, map[string]interface{}{
+			"repository": map[string]interface{}{
+				"pullRequest": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes":    nodes,
+						"pageInfo": map[string]interface{}{"hasNextPage": hasNext, "endCursor": "cursor-1"},
+					},
+				},
+			},
+		})
+	}}
+}
+
+func TestServiceAutoEventApprovesWithoutComments(t *testing.T) {
+	api := autoEventAPI(t, []map[string]interface{}{
+		threadPayload(false, "PRR_other"),
+		threadPayload(true, "PRR_mine"),
+	})
+
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	decision, err := svc.AutoEvent(pr, "PRR_mine", AutoEventOptions{RequestChanges: true})
+	require.NoError(t, err)
+	assert.Equal(t, "APPROVE", decision.Event)
+	assert.Zero(t, decision.UnresolvedThreads)
+}
+
+func TestServiceAutoEventCommentsWhenUnresolvedThreadsExist(t *testing.T) {
+	pages := [][]map[string]interface{}{
+		{threadPayload(false, "PRR_other", "PRR_mine")},
+		{threadPayload(false, "PRR_mine"), threadPayload(true, "PRR_mine")},
+	}
+
+	svc := NewService(autoEventAPI(t, pages...))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	decision, err := svc.AutoEvent(pr, "PRR_mine", AutoEventOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "COMMENT", decision.Event)
+	assert.Equal(t, 2, decision.UnresolvedThreads)
+
+	svc = NewService(autoEventAPI(t, pages...))
+	decision, err = svc.AutoEvent(pr, "PRR_mine", AutoEventOptions{RequestChanges: true})
+	require.NoError(t, err)
+	assert.Equal(t, "REQUEST_CHANGES", decision.Event)
+}
+
+func TestServiceAutoEventMissingPullRequest(t *testing.T) {
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": nil}})
+	}}
+
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	_, err := svc.AutoEvent(pr, "PRR_mine", AutoEventOptions{})
+	require.Error(t, err)
+	assert.EqualError(t, err, "pull request octo/demo#7 on github.com not found or inaccessible (check spelling, access, and GH_HOST)")
+}
@@ -1,12 +1,14 @@
 package review
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/agynio/gh-pr-review/internal/ghcli"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 )
 
@@ -37,7 +39,7 @@ type ReviewUser struct {
 func (s *Service) LatestSubmitted(pr resolver.Identity, opts LatestOptions) (*ReviewSummary, error) {
 	reviewer := strings.TrimSpace(opts.Reviewer)
 	if reviewer == "" {
-		login, err := s.currentLogin()
+		login, err := s.CurrentLogin()
 		if err != nil {
 			return nil, fmt.Errorf("resolve authenticated user: %w", err)
 		}
@@ -55,37 +57,27 @@ func (s *Service) LatestSubmitted(pr resolver.Identity, opts LatestOptions) (*Re
 		hasSubmission bool
 	)
 
-	for current := page; ; current++ {
-		var chunk []restReview
-		params := map[string]string{
-			"per_page": strconv.Itoa(perPage),
-			"page":     strconv.Itoa(current),
-		}
-		path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", pr.Owner, pr.Repo, pr.Number)
-		if err := s.API.REST("GET", path, params, nil, &chunk); err != nil {
-			return nil, err
-		}
-
-		if len(chunk) == 0 {
-			break
+	params := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", pr.Owner, pr.Repo, pr.Number)
+	err := ghcli.RESTPaginate(s.API, "GET", path, params, func(item json.RawMessage) error {
+		var review restReview
+		if err := json.Unmarshal(item, &review); err != nil {
+			return fmt.Errorf("decode review: %w", err)
 		}
-
-		for _, review := range chunk {
-			if !strings.EqualFold(review.User.Login, reviewer) {
-				continue
-			}
-			if review.SubmittedAt == nil {
-				continue
-			}
-			if !hasSubmission || review.SubmittedAt.After(*latest.SubmittedAt) || (review.SubmittedAt.Equal(*latest.SubmittedAt) && review.ID > latest.ID) {
-				latest = review
-				hasSubmission = true
-			}
+		if !strings.EqualFold(review.User.Login, reviewer) || review.SubmittedAt == nil {
+			return nil
 		}
-
-		if len(chunk) < perPage {
-			break
+		if !hasSubmission || review.SubmittedAt.After(*latest.SubmittedAt) || (review.SubmittedAt.Equal(*latest.SubmittedAt) && review.ID > latest.ID) {
+			latest = review
+			hasSubmission = true
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if !hasSubmission {
@@ -133,7 +125,8 @@ func clampPerPage(value int) int {
 	}
 }
 
-func (s *Service) currentLogin() (string, error) {
+// CurrentLogin returns the authenticated user's login from the REST user endpoint.
+func (s *Service) CurrentLogin() (string, error) {
 	var user struct {
 		Login string `json:"login"`
 	}
@@ -3,9 +3,12 @@ package review
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/agynio/gh-pr-review/internal/ghcli"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -124,3 +127,24 @@ func TestLatestSubmittedNoMatches(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no submitted reviews")
 }
+
+func TestLatestSubmittedFollowsLinkHeader(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"id":31,"state":"APPROVED","submitted_at":"2024-06-02T09:00:00Z","user":{"login":"octocat"}}]`))
+			return
+		}
+		// The first page is short, yet the Link header says more reviews follow.
+		w.Header().Set("Link", "<"+server.URL+"/repos/octo/demo/pulls/7/reviews?per_page=100&page=2>; rel=\"next\"")
+		_, _ = w.Write([]byte(`[{"id":30,"state":"COMMENTED","submitted_at":"2024-06-01T09:00:00Z","user":{"login":"octocat"}}]`))
+	}))
+	defer server.Close()
+
+	svc := NewService(&ghcli.HTTPClient{Host: "github.com", RESTURL: server.URL})
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	summary, err := svc.LatestSubmitted(pr, LatestOptions{Reviewer: "octocat"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(31), summary.ID)
+	assert.Equal(t, "APPROVED", summary.State)
+}
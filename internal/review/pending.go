@@ -10,6 +10,9 @@ import (
 	"github.com/agynio/gh-pr-review/internal/resolver"
 )
 
+// ErrNoPendingReviews indicates the reviewer has no pending review on the pull request.
+var ErrNoPendingReviews = errors.New("no pending reviews")
+
 // PendingOptions configures lookup of the latest pending review for a reviewer.
 type PendingOptions struct {
 	Reviewer string
@@ -129,7 +132,7 @@ func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([
 
 		repo := response.Data.Repository
 		if repo == nil || repo.PullRequest == nil || repo.PullRequest.Reviews == nil {
-			return nil, reviewer, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+			return nil, reviewer, &resolver.NotFoundError{Identity: pr}
 		}
 
 		reviews := repo.PullRequest.Reviews
@@ -205,7 +208,7 @@ func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([
 	}
 
 	if len(timedSummaries) == 0 {
-		return nil, reviewer, fmt.Errorf("no pending reviews for %s", reviewer)
+		return nil, reviewer, fmt.Errorf("%w for %s", ErrNoPendingReviews, reviewer)
 	}
 
 	sort.Slice(timedSummaries, func(i, j int) bool {
@@ -230,7 +233,7 @@ func (s *Service) LatestPending(pr resolver.Identity, opts PendingOptions) (*Pen
 		return nil, err
 	}
 	if len(summaries) == 0 {
-		return nil, fmt.Errorf("no pending reviews for %s", reviewer)
+		return nil, fmt.Errorf("%w for %s", ErrNoPendingReviews, reviewer)
 	}
 
 	latest := summaries[len(summaries)-1]
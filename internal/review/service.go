@@ -22,12 +22,18 @@ type ReviewState struct {
 	ID          string  `json:"id"`
 	State       string  `json:"state"`
 	SubmittedAt *string `json:"submitted_at,omitempty"`
+	// Reused is set when StartOrReuse returned an existing pending review.
+	Reused bool `json:"reused,omitempty"`
 }
 
 // SubmitStatus represents the outcome of a review submission mutation.
 type SubmitStatus struct {
 	Success bool
 	Errors  []ghcli.GraphQLErrorEntry
+	// HTMLURL links to the submitted review. GitHub's url is preferred; when
+	// it is blank the link is derived from the pull request URL and the
+	// review's database id.
+	HTMLURL string
 }
 
 // ReviewThread represents an inline comment thread added to a pending review.
@@ -121,6 +127,21 @@ func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, e
 	return &state, nil
 }
 
+// StartOrReuse returns the viewer's latest pending review when one exists and
+// opens a new one otherwise. The lookup and the creation are separate calls,
+// so two concurrent invocations can both find nothing and both create a
+// review; callers that start reviews in parallel must serialize themselves.
+func (s *Service) StartOrReuse(pr resolver.Identity, commitOID string) (*ReviewState, error) {
+	latest, err := s.LatestPending(pr, PendingOptions{})
+	if errors.Is(err, ErrNoPendingReviews) {
+		return s.Start(pr, commitOID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewState{ID: latest.ID, State: latest.State, Reused: true}, nil
+}
+
 // AddThread adds an inline review comment thread to an existing pending review.
 func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThread, error) {
 	trimmedID := strings.TrimSpace(input.ReviewID)
@@ -138,10 +159,22 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 	if input.Line <= 0 {
 		return nil, errors.New("line must be positive")
 	}
+	side, err := NormalizeSide(input.Side)
+	if err != nil {
+		return nil, err
+	}
+	var startSide *string
+	if input.StartSide != nil {
+		normalized, err := NormalizeSide(*input.StartSide)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start side: %w", err)
+		}
+		startSide = &normalized
+	}
 
 	trimmedBody := strings.TrimSpace(input.Body)
 	if trimmedBody == "" {
-		return nil, errors.New("body is required")
+		return nil, errors.New("comment body is required")
 	}
 
 	const mutation = `mutation($input:AddPullRequestReviewThreadInput!){
@@ -154,14 +187,14 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 		"pullRequestReviewId": trimmedID,
 		"path":                trimmedPath,
 		"line":                input.Line,
-		"side":                input.Side,
+		"side":                side,
 		"body":                trimmedBody,
 	}
 	if input.StartLine != nil {
 		graphqlInput["startLine"] = *input.StartLine
 	}
-	if input.StartSide != nil {
-		graphqlInput["startSide"] = *input.StartSide
+	if startSide != nil {
+		graphqlInput["startSide"] = *startSide
 	}
 
 	payload := map[string]interface{}{
@@ -197,8 +230,22 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 	return &result, nil
 }
 
+// NormalizeSide normalizes a diff side to LEFT or RIGHT, case-insensitively.
+// An empty side defaults to RIGHT, the side inline comments usually target.
+func NormalizeSide(side string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(side))
+	switch normalized {
+	case "":
+		return "RIGHT", nil
+	case "LEFT", "RIGHT":
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid side %q: must be LEFT or RIGHT", side)
+	}
+}
+
 // Submit finalizes a pending review with the given event and optional body.
-func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus, error) {
+func (s *Service) Submit(pr resolver.Identity, input SubmitInput) (*SubmitStatus, error) {
 	reviewID := strings.TrimSpace(input.ReviewID)
 	if reviewID == "" {
 		return nil, errors.New("review id is required")
@@ -220,7 +267,14 @@ func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus,
 
 	variables := map[string]interface{}{"input": graphqlInput}
 
-	var response struct{}
+	var response struct {
+		SubmitPullRequestReview struct {
+			PullRequestReview *struct {
+				DatabaseID *int64 `json:"databaseId"`
+				URL        string `json:"url"`
+			} `json:"pullRequestReview"`
+		} `json:"submitPullRequestReview"`
+	}
 	if err := s.API.GraphQL(query, variables, &response); err != nil {
 		var gqlErr *ghcli.GraphQLError
 		if errors.As(err, &gqlErr) {
@@ -229,7 +283,14 @@ func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus,
 		return nil, err
 	}
 
-	return &SubmitStatus{Success: true}, nil
+	status := &SubmitStatus{Success: true}
+	if prr := response.SubmitPullRequestReview.PullRequestReview; prr != nil {
+		status.HTMLURL = strings.TrimSpace(prr.URL)
+		if status.HTMLURL == "" && prr.DatabaseID != nil && *prr.DatabaseID > 0 {
+			status.HTMLURL = fmt.Sprintf("%s#pullrequestreview-%d", pr.URL(), *prr.DatabaseID)
+		}
+	}
+	return status, nil
 }
 
 func (s *Service) currentViewer() (string, error) {
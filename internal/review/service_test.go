@@ -3,6 +3,8 @@ package review
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/agynio/gh-pr-review/internal/ghcli"
@@ -81,6 +83,68 @@ func TestServiceStart(t *testing.T) {
 	assert.Equal(t, 2, call)
 }
 
+// startOrReuseFake answers the viewer and pending-review lookups with the given
+// pending review IDs and the start mutations with PRR_new.
+func startOrReuseFake(t *testing.T, pending []string, created *bool) *fakeAPI {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			return assign(result, map[string]interface{}{"data": map[string]interface{}{"viewer": map[string]interface{}{"login": "casey"}}})
+		case strings.Contains(query, "PendingReviews"):
+			nodes := make([]map[string]interface{}, 0, len(pending))
+			for i, id := range pending {
+				nodes = append(nodes, map[string]interface{}{
+					"id":         id,
+					"databaseId": i + 1,
+					"state":      "PENDING",
+					"createdAt":  fmt.Sprintf("2024-06-01T1%d:00:00Z", i),
+					"author":     map[string]interface{}{"login": "casey"},
+				})
+			}
+			return assign(result, map[string]interface{}{"data": map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+				"reviews": map[string]interface{}{"nodes": nodes, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+			}}}})
+		case strings.Contains(query, "addPullRequestReview"):
+			*created = true
+			return assign(result, map[string]interface{}{"addPullRequestReview": map[string]interface{}{
+				"pullRequestReview": map[string]interface{}{"id": "PRR_new", "state": "PENDING"},
+			}})
+		case strings.Contains(query, "headRefOid"):
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"id": "PR_node", "headRefOid": "abc123"}}})
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", query)
+			return nil
+		}
+	}
+	return api
+}
+
+func TestServiceStartOrReuseReturnsExistingPending(t *testing.T) {
+	created := false
+	svc := NewService(startOrReuseFake(t, []string{"PRR_old", "PRR_latest"}, &created))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	state, err := svc.StartOrReuse(pr, "")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "PRR_latest", state.ID)
+	assert.Equal(t, "PENDING", state.State)
+	assert.True(t, state.Reused)
+}
+
+func TestServiceStartOrReuseStartsWhenNonePending(t *testing.T) {
+	created := false
+	svc := NewService(startOrReuseFake(t, nil, &created))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	state, err := svc.StartOrReuse(pr, "")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "PRR_new", state.ID)
+	assert.False(t, state.Reused)
+}
+
 func TestServiceStartErrorOnEmptyReview(t *testing.T) {
 	api := &fakeAPI{}
 	step := 0
@@ -233,6 +297,58 @@ func TestServiceAddThreadRequiresPath(t *testing.T) {
 	assert.Contains(t, err.Error(), "path is required")
 }
 
+func TestServiceAddThreadRejectsBlankBody(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	for _, body := range []string{"", " \n\t "} {
+		_, err := svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: body})
+		require.Error(t, err)
+		assert.EqualError(t, err, "comment body is required")
+	}
+}
+
+func TestServiceAddThreadNormalizesSide(t *testing.T) {
+	for _, tc := range []struct {
+		side string
+		want string
+	}{
+		{side: "", want: "RIGHT"},
+		{side: "  ", want: "RIGHT"},
+		{side: "left", want: "LEFT"},
+		{side: " Right ", want: "RIGHT"},
+	} {
+		t.Run(tc.side, func(t *testing.T) {
+			api := &fakeAPI{}
+			api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				input, ok := variables["input"].(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, tc.want, input["side"])
+				assert.Equal(t, tc.want, input["startSide"])
+				return assign(result, map[string]interface{}{"addPullRequestReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{"id": "THR1", "path": "file.go", "line": 10},
+				}})
+			}
+
+			pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+			start, startSide := 8, tc.side
+			_, err := NewService(api).AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: tc.side, StartLine: &start, StartSide: &startSide, Body: "note"})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceAddThreadRejectsInvalidSide(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	_, err := svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "up", Body: "note"})
+	require.Error(t, err)
+	assert.EqualError(t, err, `invalid side "up": must be LEFT or RIGHT`)
+}
+
 func TestServiceSubmit(t *testing.T) {
 	api := &fakeAPI{}
 	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
@@ -261,6 +377,48 @@ func TestServiceSubmit(t *testing.T) {
 	assert.Empty(t, status.Errors)
 }
 
+func TestServiceSubmitReturnsReviewURL(t *testing.T) {
+	cases := []struct {
+		name   string
+		review map[string]interface{}
+		want   string
+	}{
+		{
+			name:   "server url preferred",
+			review: map[string]interface{}{"databaseId": 42, "url": "https://github.com/octo/demo/pull/7#pullrequestreview-42"},
+			want:   "https://github.com/octo/demo/pull/7#pullrequestreview-42",
+		},
+		{
+			name:   "blank url falls back to database id",
+			review: map[string]interface{}{"databaseId": 42, "url": ""},
+			want:   "https://github.com/octo/demo/pull/7#pullrequestreview-42",
+		},
+		{
+			name:   "no database id",
+			review: map[string]interface{}{"url": ""},
+			want:   "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakeAPI{}
+			api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+				return assign(result, map[string]interface{}{
+					"submitPullRequestReview": map[string]interface{}{"pullRequestReview": tc.review},
+				})
+			}
+
+			svc := NewService(api)
+			pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+			status, err := svc.Submit(pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "APPROVE"})
+			require.NoError(t, err)
+			assert.True(t, status.Success)
+			assert.Equal(t, tc.want, status.HTMLURL)
+		})
+	}
+}
+
 func TestServiceSubmitHandlesNullReviewData(t *testing.T) {
 	api := &fakeAPI{}
 	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
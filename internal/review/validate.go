@@ -0,0 +1,139 @@
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// TargetValidation reports whether an inline comment target can be commented on.
+type TargetValidation struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var patchHunkRE = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// ValidateCommentTarget checks input's path, line, and side (and the start of
+// a multi-line range) against the pull request's diff without creating a
+// thread. Only lines inside a diff hunk accept comments: additions and context
+// on the RIGHT side, deletions and context on the LEFT. Targets outside the
+// diff yield Valid false with a Reason rather than an error.
+func (s *Service) ValidateCommentTarget(pr resolver.Identity, input ThreadInput) (*TargetValidation, error) {
+	path := strings.TrimSpace(input.Path)
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+	if input.Line <= 0 {
+		return nil, errors.New("line must be positive")
+	}
+
+	side, err := NormalizeSide(input.Side)
+	if err != nil {
+		return nil, err
+	}
+	startSide := side
+	if input.StartSide != nil {
+		startSide, err = NormalizeSide(*input.StartSide)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start side: %w", err)
+		}
+	}
+
+	file, err := s.changedFile(pr, path)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return &TargetValidation{Reason: fmt.Sprintf("%s is not changed in the pull request", path)}, nil
+	}
+	if file.Patch == "" {
+		return &TargetValidation{Reason: fmt.Sprintf("%s has no textual diff (binary or too large)", path)}, nil
+	}
+
+	hunks := diffHunkLines(file.Patch)
+	endHunk, ok := hunks[side][input.Line]
+	if !ok {
+		return &TargetValidation{Reason: fmt.Sprintf("line %d (%s) is not part of the diff for %s", input.Line, side, path)}, nil
+	}
+	if input.StartLine != nil {
+		startHunk, ok := hunks[startSide][*input.StartLine]
+		if !ok {
+			return &TargetValidation{Reason: fmt.Sprintf("start line %d (%s) is not part of the diff for %s", *input.StartLine, startSide, path)}, nil
+		}
+		if startHunk != endHunk {
+			return &TargetValidation{Reason: fmt.Sprintf("lines %d-%d span more than one diff hunk in %s", *input.StartLine, input.Line, path)}, nil
+		}
+	}
+	return &TargetValidation{Valid: true}, nil
+}
+
+type changedFile struct {
+	Filename string `json:"filename"`
+	Patch    string `json:"patch"`
+}
+
+// errFileFound stops paging through the pull request's files once the
+// requested one has been seen.
+var errFileFound = errors.New("file found")
+
+// changedFile pages through the pull request's files and returns the entry
+// for path, or nil when the pull request does not touch it.
+func (s *Service) changedFile(pr resolver.Identity, path string) (*changedFile, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/files", pr.Owner, pr.Repo, pr.Number)
+	var found *changedFile
+	err := ghcli.RESTPaginate(s.API, "GET", endpoint, map[string]string{"per_page": "100"}, func(item json.RawMessage) error {
+		var file changedFile
+		if err := json.Unmarshal(item, &file); err != nil {
+			return fmt.Errorf("decode changed file: %w", err)
+		}
+		if file.Filename != path {
+			return nil
+		}
+		found = &file
+		return errFileFound
+	})
+	if err != nil && !errors.Is(err, errFileFound) {
+		return nil, err
+	}
+	return found, nil
+}
+
+// diffHunkLines maps each side of a unified diff patch to its commentable
+// line numbers, recording the index of the hunk each line belongs to.
+func diffHunkLines(patch string) map[string]map[int]int {
+	lines := map[string]map[int]int{"LEFT": {}, "RIGHT": {}}
+	hunk := -1
+	var oldLine, newLine int
+	for _, text := range strings.Split(patch, "\n") {
+		if header := patchHunkRE.FindStringSubmatch(text); header != nil {
+			hunk++
+			oldLine, _ = strconv.Atoi(header[1])
+			newLine, _ = strconv.Atoi(header[2])
+			continue
+		}
+		if hunk < 0 || text == "" || strings.HasPrefix(text, `\`) {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(text, "+"):
+			lines["RIGHT"][newLine] = hunk
+			newLine++
+		case strings.HasPrefix(text, "-"):
+			lines["LEFT"][oldLine] = hunk
+			oldLine++
+		default:
+			lines["LEFT"][oldLine] = hunk
+			lines["RIGHT"][newLine] = hunk
+			oldLine++
+			newLine++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,105 @@
+package review
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// validatePatch changes lines 10-12 of main.go: old line 11 is replaced by new
+// lines 11-12, and a second hunk starts at new line 40.
+const validatePatch = "@@ -10,3 +10,4 @@ func main() {\n" +
+	" \tctx := context.Background()\n" +
+	"-\trun(ctx)\n" +
+	"+\tif err := run(ctx); err != nil {\n" +
+	"+\t\tlog.Fatal(err)\n" +
+	" }\n" +
+	"@@ -38,2 +40,3 @@ func run(ctx context.Context) error {\n" +
+	" \treturn nil\n" +
+	"+\t// done\n" +
+	" }"
+
+func validateFilesAPI(t *testing.T) *fakeAPI {
+	api := &fakeAPI{}
+	api.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "GET", method)
+		require.Equal(t, "repos/octo/demo/pulls/7/files", path)
+		switch params["page"] {
+		case "1":
+			files := make([]map[string]interface{}, 100)
+			for i := range files {
+				files[i] = map[string]interface{}{"filename": "vendor/file.go", "patch": "@@ -1 +1 @@\n-a\n+b"}
+			}
+			return assign(result, files)
+		case "2":
+			return assign(result, []map[string]interface{}{
+				{"filename": "main.go", "patch": validatePatch},
+				{"filename": "logo.png"},
+			})
+		default:
+			return errors.New("unexpected page " + params["page"])
+		}
+	}
+	return api
+}
+
+func TestValidateCommentTargetAcceptsDiffLines(t *testing.T) {
+	svc := NewService(validateFilesAPI(t))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	start := 10
+	left := "LEFT"
+	lowerLeft := " left "
+
+	for name, input := range map[string]ThreadInput{
+		"added line":         {Path: "main.go", Line: 12, Side: "RIGHT"},
+		"context line":       {Path: "main.go", Line: 13, Side: "RIGHT"},
+		"deleted line":       {Path: "main.go", Line: 11, Side: "LEFT"},
+		"second hunk":        {Path: "main.go", Line: 41, Side: "RIGHT"},
+		"range in one hunk":  {Path: "main.go", Line: 12, Side: "RIGHT", StartLine: &start},
+		"range across sides": {Path: "main.go", Line: 12, Side: "RIGHT", StartLine: &start, StartSide: &left},
+		"lowercase side":     {Path: "main.go", Line: 11, Side: "left"},
+		"default side":       {Path: "main.go", Line: 12},
+		"lowercase start":    {Path: "main.go", Line: 12, Side: "right", StartLine: &start, StartSide: &lowerLeft},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := svc.ValidateCommentTarget(pr, input)
+			require.NoError(t, err)
+			assert.Equal(t, &TargetValidation{Valid: true}, result)
+		})
+	}
+}
+
+func TestValidateCommentTargetRejectsInvalidSide(t *testing.T) {
+	svc := NewService(&fakeAPI{})
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+
+	_, err := svc.ValidateCommentTarget(pr, ThreadInput{Path: "main.go", Line: 12, Side: "up"})
+	assert.EqualError(t, err, `invalid side "up": must be LEFT or RIGHT`)
+}
+
+func TestValidateCommentTargetRejectsOutOfRange(t *testing.T) {
+	svc := NewService(validateFilesAPI(t))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	start := 12
+
+	for name, tc := range map[string]struct {
+		input  ThreadInput
+		reason string
+	}{
+		"line outside hunks": {ThreadInput{Path: "main.go", Line: 30, Side: "RIGHT"}, "line 30 (RIGHT) is not part of the diff for main.go"},
+		"new line on left":   {ThreadInput{Path: "main.go", Line: 40, Side: "LEFT"}, "line 40 (LEFT) is not part of the diff for main.go"},
+		"range across hunks": {ThreadInput{Path: "main.go", Line: 41, Side: "RIGHT", StartLine: &start}, "lines 12-41 span more than one diff hunk in main.go"},
+		"unchanged file":     {ThreadInput{Path: "README.md", Line: 1, Side: "RIGHT"}, "README.md is not changed in the pull request"},
+		"binary file":        {ThreadInput{Path: "logo.png", Line: 1, Side: "RIGHT"}, "logo.png has no textual diff (binary or too large)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := svc.ValidateCommentTarget(pr, tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, &TargetValidation{Reason: tc.reason}, result)
+		})
+	}
+}
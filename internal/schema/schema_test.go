@@ -0,0 +1,67 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/schema"
+)
+
+func TestGenerateReportIncludesNestedThreadComments(t *testing.T) {
+	s := schema.Generate(report.Report{})
+
+	assert.Equal(t, schema.Draft, s.Schema)
+	assert.Equal(t, "Report", s.Title)
+	assert.Equal(t, []string{"reviews"}, s.Required)
+
+	reviews := s.Properties["reviews"]
+	require.NotNil(t, reviews)
+	assert.Equal(t, "array", reviews.Type)
+	comments := reviews.Items.Properties["comments"]
+	require.NotNil(t, comments)
+	threadComments := comments.Items.Properties["thread_comments"]
+	require.NotNil(t, threadComments)
+	assert.Equal(t, "array", threadComments.Type)
+	assert.Contains(t, threadComments.Items.Required, "author_login")
+	assert.NotContains(t, threadComments.Items.Required, "comment_node_id")
+}
+
+type sample struct {
+	Name     string          `json:"name"`
+	Count    *int            `json:"count"`
+	Note     *string         `json:"note,omitempty"`
+	Labels   map[string]int  `json:"labels,omitempty"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
+	Skipped  string          `json:"-"`
+	internal string
+	Embedded
+}
+
+type Embedded struct {
+	Flag bool `json:"flag"`
+}
+
+func TestGenerateFieldRules(t *testing.T) {
+	data, err := json.Marshal(schema.Generate(sample{}))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "sample",
+		"type": "object",
+		"required": ["name", "count", "flag"],
+		"properties": {
+			"name": {"type": "string"},
+			"count": {"type": ["integer", "null"]},
+			"note": {"type": "string"},
+			"labels": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"raw": {},
+			"flag": {"type": "boolean"}
+		},
+		"additionalProperties": false
+	}`, string(data))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/pathglob"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 )
 
@@ -25,14 +26,46 @@ func NewService(api ghcli.API) *Service {
 type ListOptions struct {
 	OnlyUnresolved bool
 	MineOnly       bool
+	// OthersOnly drops threads whose first comment the viewer wrote.
+	OthersOnly bool
+	// OnlyOutdated keeps threads GitHub marks outdated; OnlyNotOutdated drops them.
+	OnlyOutdated    bool
+	OnlyNotOutdated bool
+	// Paths keeps threads whose path matches any of the globs ("**" spans directories).
+	Paths []string
+	// Sort picks the ordering key (default SortUpdated).
+	Sort SortKey
+	// Direction overrides the key's default direction.
+	Direction SortDirection
 }
 
+// SortKey names the field threads are ordered by.
+type SortKey string
+
+const (
+	// SortUpdated orders by the latest comment update (newest first by default).
+	SortUpdated SortKey = "updated"
+	// SortPath orders by file path, then line (A to Z by default).
+	SortPath SortKey = "path"
+	// SortCreated orders by the thread's first comment (newest first by default).
+	SortCreated SortKey = "created"
+)
+
+// SortDirection selects ascending or descending order; empty uses the key's default.
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
 // Thread represents a normalized review thread payload for JSON output.
 type Thread struct {
 	ThreadID   string     `json:"threadId"`
 	IsResolved bool       `json:"isResolved"`
 	ResolvedBy *string    `json:"resolvedBy,omitempty"`
 	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
 	Path       string     `json:"path"`
 	Line       *int       `json:"line,omitempty"`
 	IsOutdated bool       `json:"isOutdated"`
@@ -56,6 +89,17 @@ type pullContext struct {
 
 // List fetches review threads for the provided pull request, applies filters, and returns sorted results.
 func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error) {
+	for _, pattern := range opts.Paths {
+		if err := pathglob.Validate(pattern); err != nil {
+			return nil, fmt.Errorf("invalid --path glob %q: %w", pattern, err)
+		}
+	}
+
+	less, err := threadComparator(opts.Sort, opts.Direction)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, err := s.loadPullContext(pr)
 	if err != nil {
 		return nil, err
@@ -72,6 +116,12 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 		if opts.OnlyUnresolved && node.IsResolved {
 			continue
 		}
+		if opts.OnlyOutdated && !node.IsOutdated {
+			continue
+		}
+		if opts.OnlyNotOutdated && node.IsOutdated {
+			continue
+		}
 
 		mine := node.ViewerCanResolve || node.ViewerCanUnresolve
 		var (
@@ -88,10 +138,29 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 				hasStamp = true
 			}
 		}
+		for _, comment := range node.LatestComment.Nodes {
+			if !hasStamp || comment.UpdatedAt.After(latest) {
+				latest = comment.UpdatedAt
+				hasStamp = true
+			}
+		}
 
 		if opts.MineOnly && !mine {
 			continue
 		}
+		if opts.OthersOnly && len(node.Comments.Nodes) > 0 && node.Comments.Nodes[0].ViewerDidAuthor {
+			continue
+		}
+
+		if len(opts.Paths) > 0 {
+			matched, err := pathglob.MatchAny(opts.Paths, node.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
 
 		var resolvedBy *string
 		if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
@@ -105,6 +174,12 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 			updatedAt = &ts
 		}
 
+		var createdAt *time.Time
+		if comments := node.Comments.Nodes; len(comments) > 0 && !comments[0].CreatedAt.IsZero() {
+			ts := comments[0].CreatedAt
+			createdAt = &ts
+		}
+
 		var linePtr *int
 		if node.Line != nil {
 			value := *node.Line
@@ -116,6 +191,7 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 			IsResolved: node.IsResolved,
 			ResolvedBy: resolvedBy,
 			UpdatedAt:  updatedAt,
+			CreatedAt:  createdAt,
 			Path:       node.Path,
 			Line:       linePtr,
 			IsOutdated: node.IsOutdated,
@@ -123,27 +199,80 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 	}
 
 	sort.SliceStable(allThreads, func(i, j int) bool {
-		left := allThreads[i].UpdatedAt
-		right := allThreads[j].UpdatedAt
-
-		switch {
-		case left == nil && right == nil:
-			return allThreads[i].ThreadID < allThreads[j].ThreadID
-		case left == nil:
-			return false
-		case right == nil:
-			return true
-		default:
-			if left.Equal(*right) {
-				return allThreads[i].ThreadID < allThreads[j].ThreadID
-			}
-			return left.After(*right)
-		}
+		return less(allThreads[i], allThreads[j])
 	})
 
 	return allThreads, nil
 }
 
+// threadComparator returns the ordering for key and direction. Threads
+// without the sort timestamp always come last, and ties fall back to the
+// thread ID so output is stable across runs.
+func threadComparator(key SortKey, direction SortDirection) (func(a, b Thread) bool, error) {
+	if key == "" {
+		key = SortUpdated
+	}
+
+	var ascending bool
+	switch direction {
+	case "":
+		ascending = key == SortPath
+	case SortAscending:
+		ascending = true
+	case SortDescending:
+		ascending = false
+	default:
+		return nil, fmt.Errorf("invalid sort direction %q (allowed: asc, desc)", direction)
+	}
+
+	byTime := func(stamp func(Thread) *time.Time) func(a, b Thread) bool {
+		return func(a, b Thread) bool {
+			left, right := stamp(a), stamp(b)
+			switch {
+			case left == nil && right == nil:
+				return a.ThreadID < b.ThreadID
+			case left == nil:
+				return false
+			case right == nil:
+				return true
+			case left.Equal(*right):
+				return a.ThreadID < b.ThreadID
+			case ascending:
+				return left.Before(*right)
+			default:
+				return left.After(*right)
+			}
+		}
+	}
+
+	switch key {
+	case SortUpdated:
+		return byTime(func(t Thread) *time.Time { return t.UpdatedAt }), nil
+	case SortCreated:
+		return byTime(func(t Thread) *time.Time { return t.CreatedAt }), nil
+	case SortPath:
+		return func(a, b Thread) bool {
+			if a.Path != b.Path {
+				return (a.Path < b.Path) == ascending
+			}
+			left, right := lineOrZero(a.Line), lineOrZero(b.Line)
+			if left != right {
+				return (left < right) == ascending
+			}
+			return a.ThreadID < b.ThreadID
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid --sort value %q (allowed: updated, path, created)", key)
+	}
+}
+
+func lineOrZero(line *int) int {
+	if line == nil {
+		return 0
+	}
+	return *line
+}
+
 // Resolve marks a thread as resolved when permissions and current state allow it.
 func (s *Service) Resolve(pr resolver.Identity, opts ActionOptions) (ActionResult, error) {
 	return s.changeResolution(pr, opts, true)
@@ -180,10 +309,18 @@ type threadNode struct {
 	Comments struct {
 		Nodes []struct {
 			ViewerDidAuthor bool      `json:"viewerDidAuthor"`
+			CreatedAt       time.Time `json:"createdAt"`
 			UpdatedAt       time.Time `json:"updatedAt"`
 			DatabaseID      int64     `json:"databaseId"`
 		} `json:"nodes"`
 	} `json:"comments"`
+	// LatestComment holds the thread's newest comment, which falls outside
+	// Comments once a thread has more than 100 of them.
+	LatestComment struct {
+		Nodes []struct {
+			UpdatedAt time.Time `json:"updatedAt"`
+		} `json:"nodes"`
+	} `json:"latestComment"`
 }
 
 func (s *Service) fetchThreads(nodeID string, after *string) (*threadsQueryResponse, error) {
@@ -213,7 +350,7 @@ func (s *Service) collectThreads(ctx pullContext) ([]threadNode, error) {
 
 		node := resp.Node
 		if node == nil || node.ReviewThreads == nil {
-			return nil, fmt.Errorf("pull request %d not found on %s", ctx.identity.Number, ctx.identity.Host)
+			return nil, &resolver.NotFoundError{Identity: ctx.identity}
 		}
 
 		threads := node.ReviewThreads
@@ -260,10 +397,10 @@ func (s *Service) loadPullContext(pr resolver.Identity) (pullContext, error) {
 	}
 	path := fmt.Sprintf("repos/%s/%s/pulls/%d", canonical.Owner, canonical.Repo, canonical.Number)
 	if err := s.API.REST("GET", path, nil, nil, &pull); err != nil {
-		return pullContext{}, fmt.Errorf("pull request %d not found on %s: %w", canonical.Number, canonical.Host, err)
+		return pullContext{}, &resolver.NotFoundError{Identity: canonical, Err: err}
 	}
 	if strings.TrimSpace(pull.NodeID) == "" {
-		return pullContext{}, fmt.Errorf("pull request %d missing node identifier on %s", canonical.Number, canonical.Host)
+		return pullContext{}, fmt.Errorf("pull request missing node identifier: %s", canonical.URL())
 	}
 
 	return pullContext{identity: canonical, nodeID: pull.NodeID}, nil
@@ -369,6 +506,12 @@ query Threads($id: ID!, $after: String) {
             nodes {
               databaseId
               viewerDidAuthor
+              createdAt
+              updatedAt
+            }
+          }
+          latestComment: comments(last: 1) {
+            nodes {
               updatedAt
             }
           }
@@ -419,3 +419,194 @@ func assign(dst interface{}, payload interface{}) error {
 	}
 	return json.Unmarshal(data, dst)
 }
+
+func TestServiceListFiltersByPathGlob(t *testing.T) {
+	paths := []string{"internal/file.go", "internal/report/builder.go", "cmd/root.go", "docs/USAGE.md"}
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			nodes := make([]map[string]interface{}, len(paths))
+			for i, p := range paths {
+				nodes[i] = map[string]interface{}{
+					"id":       "T" + strconv.Itoa(i+1),
+					"path":     p,
+					"comments": map[string]interface{}{"nodes": []map[string]interface{}{}},
+				}
+			}
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes":    nodes,
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+	}
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+
+	simple, err := svc.List(identity, ListOptions{Paths: []string{"cmd/*.go"}})
+	require.NoError(t, err)
+	require.Len(t, simple, 1)
+	assert.Equal(t, "cmd/root.go", simple[0].Path)
+
+	recursive, err := svc.List(identity, ListOptions{Paths: []string{"internal/**/*.go"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"T1", "T2"}, threadIDs(recursive))
+
+	combined, err := svc.List(identity, ListOptions{Paths: []string{"**/builder.go", "docs/*"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"T2", "T4"}, threadIDs(combined))
+
+	_, err = svc.List(identity, ListOptions{Paths: []string{"[bad"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --path glob")
+}
+
+func TestServiceListFiltersByOutdated(t *testing.T) {
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			empty := map[string]interface{}{"nodes": []map[string]interface{}{}}
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{
+							{"id": "T1", "path": "a.go", "isOutdated": true, "comments": empty},
+							{"id": "T2", "path": "b.go", "isOutdated": false, "comments": empty},
+							{"id": "T3", "path": "c.go", "isOutdated": true, "isResolved": true, "comments": empty},
+						},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+	}
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+
+	outdated, err := svc.List(identity, ListOptions{OnlyOutdated: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"T1", "T3"}, threadIDs(outdated))
+
+	current, err := svc.List(identity, ListOptions{OnlyNotOutdated: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T2"}, threadIDs(current))
+
+	staleOpen, err := svc.List(identity, ListOptions{OnlyOutdated: true, OnlyUnresolved: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T1"}, threadIDs(staleOpen))
+}
+
+func TestServiceListSort(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 10, 0, 0, 0, time.UTC) }
+	comment := func(created, updated int) map[string]interface{} {
+		return map[string]interface{}{"createdAt": day(created), "updatedAt": day(updated)}
+	}
+	nodes := []map[string]interface{}{
+		{"id": "T1", "path": "b.go", "line": 10, "comments": map[string]interface{}{"nodes": []map[string]interface{}{comment(1, 5)}}},
+		{"id": "T2", "path": "a.go", "line": 20, "comments": map[string]interface{}{"nodes": []map[string]interface{}{comment(3, 4)}}},
+		{"id": "T3", "path": "a.go", "line": 5, "comments": map[string]interface{}{"nodes": []map[string]interface{}{comment(2, 2), comment(6, 6)}}},
+		{"id": "T4", "path": "c.go", "comments": map[string]interface{}{"nodes": []map[string]interface{}{}}},
+	}
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes":    nodes,
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+	}
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+
+	for _, tc := range []struct {
+		sort      SortKey
+		direction SortDirection
+		want      []string
+	}{
+		{want: []string{"T3", "T1", "T2", "T4"}},
+		{sort: SortUpdated, direction: SortAscending, want: []string{"T2", "T1", "T3", "T4"}},
+		{sort: SortCreated, want: []string{"T2", "T3", "T1", "T4"}},
+		{sort: SortCreated, direction: SortAscending, want: []string{"T1", "T3", "T2", "T4"}},
+		{sort: SortPath, want: []string{"T3", "T2", "T1", "T4"}},
+		{sort: SortPath, direction: SortDescending, want: []string{"T4", "T1", "T2", "T3"}},
+	} {
+		t.Run(string(tc.sort)+"/"+string(tc.direction), func(t *testing.T) {
+			threads, err := svc.List(identity, ListOptions{Sort: tc.sort, Direction: tc.direction})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, threadIDs(threads))
+		})
+	}
+
+	_, err := svc.List(identity, ListOptions{Sort: "author"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --sort value")
+}
+
+func TestServiceListUsesLatestCommentBeyondFirstPage(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 10, 0, 0, 0, time.UTC) }
+	page := func(days ...int) map[string]interface{} {
+		nodes := make([]map[string]interface{}, len(days))
+		for i, d := range days {
+			nodes[i] = map[string]interface{}{"createdAt": day(d), "updatedAt": day(d)}
+		}
+		return map[string]interface{}{"nodes": nodes}
+	}
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{
+							// T-long has more than 100 comments: the first page ends on
+							// day 3 and only latestComment sees the day 9 reply.
+							{"id": "T-long", "path": "a.go", "comments": page(1, 2, 3), "latestComment": page(9)},
+							{"id": "T-short", "path": "b.go", "comments": page(5), "latestComment": page(5)},
+						},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+	}
+
+	threads, err := svc.List(resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}, ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-long", "T-short"}, threadIDs(threads))
+	require.NotNil(t, threads[0].UpdatedAt)
+	assert.Equal(t, day(9), *threads[0].UpdatedAt)
+	require.NotNil(t, threads[0].CreatedAt)
+	assert.Equal(t, day(1), *threads[0].CreatedAt)
+}
+
+func threadIDs(threads []Thread) []string {
+	ids := make([]string, len(threads))
+	for i, thread := range threads {
+		ids[i] = thread.ThreadID
+	}
+	return ids
+}
+
+func TestServiceListNotFoundIncludesIdentity(t *testing.T) {
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			return assign(result, map[string]interface{}{"node": nil})
+		},
+	}
+
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Host: "ghe.example.com", Number: 5}
+	_, err := svc.List(identity, ListOptions{})
+	require.Error(t, err)
+	assert.EqualError(t, err, "pull request octo/demo#5 on ghe.example.com not found or inaccessible (check spelling, access, and GH_HOST)")
+}
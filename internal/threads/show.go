@@ -0,0 +1,156 @@
+package threads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// ThreadDetail is the normalized payload for a single review thread and its
+// full comment chain.
+type ThreadDetail struct {
+	ThreadID   string          `json:"thread_id"`
+	Path       string          `json:"path"`
+	Line       *int            `json:"line,omitempty"`
+	IsResolved bool            `json:"is_resolved"`
+	ResolvedBy *string         `json:"resolved_by,omitempty"`
+	IsOutdated bool            `json:"is_outdated"`
+	Comments   []ThreadComment `json:"comments"`
+}
+
+// ThreadComment is one comment in a ThreadDetail, oldest first.
+type ThreadComment struct {
+	CommentNodeID string  `json:"comment_node_id"`
+	DatabaseID    int64   `json:"database_id"`
+	AuthorLogin   string  `json:"author_login"`
+	Body          string  `json:"body"`
+	CreatedAt     string  `json:"created_at"`
+	DiffHunk      *string `json:"diff_hunk,omitempty"`
+}
+
+// Show fetches a single review thread with all of its comments.
+func (s *Service) Show(pr resolver.Identity, threadID string) (*ThreadDetail, error) {
+	threadID = strings.TrimSpace(threadID)
+	if threadID == "" {
+		return nil, errors.New("thread id is required")
+	}
+
+	var detail *ThreadDetail
+	var after *string
+	for {
+		variables := map[string]interface{}{"id": threadID}
+		if after != nil {
+			variables["after"] = *after
+		}
+
+		var resp struct {
+			Node *showThreadNode `json:"node"`
+		}
+		if err := s.API.GraphQL(showThreadQuery, variables, &resp); err != nil {
+			return nil, err
+		}
+		node := resp.Node
+		if node == nil || node.ID == "" {
+			return nil, fmt.Errorf("thread %s not found on %s", threadID, pr.Host)
+		}
+
+		if detail == nil {
+			detail = &ThreadDetail{
+				ThreadID:   node.ID,
+				Path:       node.Path,
+				Line:       node.Line,
+				IsResolved: node.IsResolved,
+				IsOutdated: node.IsOutdated,
+				Comments:   make([]ThreadComment, 0, len(node.Comments.Nodes)),
+			}
+			if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
+				login := node.ResolvedBy.Login
+				detail.ResolvedBy = &login
+			}
+		}
+
+		for _, comment := range node.Comments.Nodes {
+			entry := ThreadComment{
+				CommentNodeID: comment.ID,
+				DatabaseID:    comment.DatabaseID,
+				Body:          comment.Body,
+				CreatedAt:     comment.CreatedAt,
+			}
+			if comment.Author != nil {
+				entry.AuthorLogin = comment.Author.Login
+			}
+			if hunk := strings.TrimSpace(comment.DiffHunk); hunk != "" {
+				value := comment.DiffHunk
+				entry.DiffHunk = &value
+			}
+			detail.Comments = append(detail.Comments, entry)
+		}
+
+		page := node.Comments.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			break
+		}
+		cursor := page.EndCursor
+		after = &cursor
+	}
+
+	return detail, nil
+}
+
+type showThreadNode struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Line       *int   `json:"line"`
+	IsResolved bool   `json:"isResolved"`
+	IsOutdated bool   `json:"isOutdated"`
+	ResolvedBy *struct {
+		Login string `json:"login"`
+	} `json:"resolvedBy"`
+	Comments struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			DatabaseID int64  `json:"databaseId"`
+			Body       string `json:"body"`
+			CreatedAt  string `json:"createdAt"`
+			DiffHunk   string `json:"diffHunk"`
+			Author     *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"nodes"`
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+	} `json:"comments"`
+}
+
+const showThreadQuery = `
+query ThreadShow($id: ID!, $after: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      id
+      path
+      line
+      isResolved
+      isOutdated
+      resolvedBy { login }
+      comments(first: 100, after: $after) {
+        nodes {
+          id
+          databaseId
+          body
+          createdAt
+          diffHunk
+          author { login }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,77 @@
+package threads
+
+import (
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func showComment(id string, dbID int64, login string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"databaseId": dbID,
+		"body":       "body " + id,
+		"createdAt":  "2025-12-03T10:00:00Z",
+		"diffHunk":   "",
+		"author":     map[string]interface{}{"login": login},
+	}
+}
+
+func TestShowCollectsAllCommentPages(t *testing.T) {
+	calls := 0
+	svc := &Service{API: &fakeAPI{
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			require.Equal(t, showThreadQuery, query)
+			require.Equal(t, "PRRT_1", variables["id"])
+			calls++
+			page := map[string]interface{}{
+				"id":         "PRRT_1",
+				"path":       "main.go",
+				"line":       nil,
+				"isResolved": false,
+				"isOutdated": true,
+				"resolvedBy": nil,
+			}
+			if calls == 1 {
+				assert.NotContains(t, variables, "after")
+				page["comments"] = map[string]interface{}{
+					"nodes":    []interface{}{showComment("C1", 1, "alice")},
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "c1"},
+				}
+			} else {
+				assert.Equal(t, "c1", variables["after"])
+				page["comments"] = map[string]interface{}{
+					"nodes":    []interface{}{showComment("C2", 2, "bob")},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				}
+			}
+			return assign(result, map[string]interface{}{"node": page})
+		},
+	}}
+
+	detail, err := svc.Show(resolver.Identity{Owner: "octo", Repo: "demo", Number: 5, Host: "github.com"}, " PRRT_1 ")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "PRRT_1", detail.ThreadID)
+	assert.True(t, detail.IsOutdated)
+	assert.Nil(t, detail.Line)
+	assert.Nil(t, detail.ResolvedBy)
+	require.Len(t, detail.Comments, 2)
+	assert.Equal(t, "alice", detail.Comments[0].AuthorLogin)
+	assert.Equal(t, int64(2), detail.Comments[1].DatabaseID)
+	assert.Nil(t, detail.Comments[0].DiffHunk)
+}
+
+func TestShowNotFoundIncludesThreadID(t *testing.T) {
+	svc := &Service{API: &fakeAPI{
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			return assign(result, map[string]interface{}{"node": nil})
+		},
+	}}
+
+	_, err := svc.Show(resolver.Identity{Owner: "octo", Repo: "demo", Number: 5, Host: "github.com"}, "PRRT_missing")
+	require.Error(t, err)
+	assert.EqualError(t, err, "thread PRRT_missing not found on github.com")
+}